@@ -20,6 +20,7 @@ func main() {
 			kexcli.GenerateCommand,
 			kexcli.UpdateCommand,
 			kexcli.AddCommand,
+			kexcli.ListCommand,
 		},
 	}
 
@@ -4,27 +4,190 @@ package domain
 type DocumentStatus string
 
 const (
-	StatusDraft   DocumentStatus = "draft"
-	StatusAdopted DocumentStatus = "adopted"
+	StatusDraft      DocumentStatus = "draft"
+	StatusAdopted    DocumentStatus = "adopted"
+	StatusDeprecated DocumentStatus = "deprecated" // Retired; readable but excluded from search
+	StatusArchived   DocumentStatus = "archived"   // Retired and kept for history only
 )
 
+// IsValid reports whether the status is empty or a known status
+func (s DocumentStatus) IsValid() bool {
+	switch s {
+	case "", StatusDraft, StatusAdopted, StatusDeprecated, StatusArchived:
+		return true
+	}
+	return false
+}
+
+// IsRetired reports whether the status is deprecated or archived
+func (s DocumentStatus) IsRetired() bool {
+	return s == StatusDeprecated || s == StatusArchived
+}
+
+// Severity tells how strictly a guideline must be followed
+type Severity string
+
+const (
+	SeverityMust   Severity = "must"   // Mandatory (e.g. "never log secrets")
+	SeverityShould Severity = "should" // Recommended; the default
+	SeverityMay    Severity = "may"    // Optional advice
+)
+
+// IsValid reports whether the severity is empty or a known level
+func (s Severity) IsValid() bool {
+	switch s {
+	case "", SeverityMust, SeverityShould, SeverityMay:
+		return true
+	}
+	return false
+}
+
+// DocumentKind tells what a document is used for
+type DocumentKind string
+
+const (
+	KindGuideline DocumentKind = "guideline" // Searchable guideline; the default
+	KindPrompt    DocumentKind = "prompt"    // Prompt template served to MCP clients, never searched
+)
+
+// IsValid reports whether the kind is empty or a known kind
+func (k DocumentKind) IsValid() bool {
+	switch k {
+	case "", KindGuideline, KindPrompt:
+		return true
+	}
+	return false
+}
+
+// OverrideMode tells how a local document overrides a referenced one
+type OverrideMode string
+
+const (
+	OverrideReplace OverrideMode = "replace" // The local document is served instead; the default
+	OverrideExtend  OverrideMode = "extend"  // The local body is appended to the referenced one as an addendum
+)
+
+// IsValid reports whether the mode is empty or a known mode
+func (m OverrideMode) IsValid() bool {
+	switch m {
+	case "", OverrideReplace, OverrideExtend:
+		return true
+	}
+	return false
+}
+
 // Document represents a single guideline document
 type Document struct {
 	ID          string         `yaml:"-"`
+	Kind        DocumentKind   `yaml:"kind"` // guideline | prompt (empty = guideline)
 	Title       string         `yaml:"title"`
 	Description string         `yaml:"description"`
 	Keywords    []string       `yaml:"keywords"`
 	Status      DocumentStatus `yaml:"status"`
+	Severity    Severity       `yaml:"severity"`   // must | should | may (empty = should)
+	AppliesTo   []string       `yaml:"appliesTo"`  // File patterns the document targets (see MatchPathPattern)
+	Related     []string       `yaml:"related"`    // IDs of related documents
+	Supersedes  []string       `yaml:"supersedes"` // IDs of documents this one replaces
+	Requires    []string       `yaml:"requires"`   // IDs of documents to read first
+	ReplacedBy  string         `yaml:"replacedBy"` // ID of the document replacing a retired one
 	Sources     []struct {
 		Name string `yaml:"name"`
 		URL  string `yaml:"url"`
 	} `yaml:"sources"`
 
+	// Local override of a referenced document
+	Overrides    string       `yaml:"overrides"`    // ID of the referenced document
+	OverrideMode OverrideMode `yaml:"overrideMode"` // replace (default) | extend
+
+	// Arguments of a prompt, rendered into its body (see RenderPrompt)
+	Arguments []PromptArgument `yaml:"arguments"`
+
 	// Body content (markdown)
 	Body string `yaml:"-"`
+	Size int    `yaml:"-"` // Body size in bytes, known before the body is loaded (0 = unknown)
 
 	// Metadata derived from file path
-	Path string `yaml:"-"`
+	Path       string `yaml:"-"`
+	Source     string `yaml:"-"` // Source or reference the document was loaded from (local root or URL)
+	SourcePath string `yaml:"-"` // Path of the markdown file within Source (Path may carry a provider prefix)
+
+	// Project policy applied when indexing
+	OverriddenBy string `yaml:"-"` // ID of the local document served instead of this one
+	Waiver       string `yaml:"-"` // Reason this guideline is waived in the project ("" = not waived)
+
+	Scopes []string `yaml:"scopes"` // Derived from directory structure unless set in frontmatter
+}
+
+// NamespaceSeparator joins a reference namespace and a document ID (e.g. org:coding.go.naming)
+const NamespaceSeparator = ":"
+
+// NamespacedID prefixes the ID with the namespace ("" leaves the ID unchanged)
+func NamespacedID(namespace, id string) string {
+	if namespace == "" {
+		return id
+	}
+	return namespace + NamespaceSeparator + id
+}
+
+// EffectiveOverrideMode returns the override mode, defaulting to replace
+func (d *Document) EffectiveOverrideMode() OverrideMode {
+	if d.OverrideMode == "" {
+		return OverrideReplace
+	}
+	return d.OverrideMode
+}
+
+// IsPrompt reports whether the document is a prompt template rather than a guideline
+func (d *Document) IsPrompt() bool {
+	return d.Kind == KindPrompt
+}
+
+// IsHidden reports whether search and listings leave the document out: it is
+// overridden by a local document, waived, or retired (unless includeRetired is set,
+// e.g. for a search filtering by status)
+func (d *Document) IsHidden(includeRetired bool) bool {
+	if d.OverriddenBy != "" || d.Waiver != "" {
+		return true
+	}
+	return !includeRetired && d.Status.IsRetired()
+}
+
+// EffectiveSeverity returns the severity, defaulting to "should"
+func (d *Document) EffectiveSeverity() Severity {
+	if d.Severity == "" {
+		return SeverityShould
+	}
+	return d.Severity
+}
+
+// EstimatedTokens approximates the token count of the body (about 4 bytes per token).
+// It returns 0 when neither the body nor its size is known.
+func (d *Document) EstimatedTokens() int {
+	size := d.Size
+	if d.Body != "" {
+		size = len(d.Body)
+	}
+	return (size + 3) / 4
+}
+
+// SearchResult is a document matched by a search, along with its relevance score
+type SearchResult struct {
+	*Document
+	Score        float64
+	MatchedField string // Field the snippet was taken from (e.g. "body", "title")
+	Snippet      string // Excerpt of the matched field with matched terms in **bold**
+	Section      string // Slug of the body section with the most matches ("" unless the body matched)
+}
 
-	Scopes []string `yaml:"-"` // Derived from directory structure
+// AppliesToPath returns the first appliesTo pattern matching the file path ("" if none)
+func (d *Document) AppliesToPath(filePath string) string {
+	if filePath == "" {
+		return ""
+	}
+	for _, pattern := range d.AppliesTo {
+		if MatchPathPattern(pattern, filePath) {
+			return pattern
+		}
+	}
+	return ""
 }
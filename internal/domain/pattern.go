@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// ValidatePathPattern reports syntax errors in a pattern accepted by MatchPathPattern
+func ValidatePathPattern(pattern string) error {
+	if pattern == "" {
+		return fmt.Errorf("empty pattern")
+	}
+	for _, alt := range expandBraces(pattern) {
+		for _, seg := range strings.Split(strings.TrimPrefix(alt, "/"), "/") {
+			if _, err := path.Match(seg, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// MatchPathPattern reports whether a file path matches a glob pattern.
+//
+// Patterns use "/" as separator; "*" and "?" match within a path segment, "**"
+// matches any number of segments and "{a,b}" matches either alternative. A pattern
+// without "/" matches the file name in any directory ("*.go"). Other patterns may
+// match anywhere in the path, so "services/payments/**" matches both
+// "services/payments/api.go" and "/home/me/repo/services/payments/api.go";
+// a leading "/" anchors them to the beginning.
+func MatchPathPattern(pattern, filePath string) bool {
+	// Clients may send Windows paths regardless of the server's OS
+	p := strings.ReplaceAll(filePath, `\`, "/")
+	for _, alt := range expandBraces(pattern) {
+		if matchAlternative(alt, p) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAlternative(pattern, p string) bool {
+	segs := splitPath(p)
+	if !strings.Contains(pattern, "/") && pattern != "**" {
+		if len(segs) == 0 {
+			return false
+		}
+		ok, _ := path.Match(pattern, segs[len(segs)-1])
+		return ok
+	}
+
+	patSegs := splitPath(pattern)
+	if strings.HasPrefix(pattern, "/") {
+		return matchSegments(patSegs, segs)
+	}
+	for start := 0; start <= len(segs); start++ {
+		if matchSegments(patSegs, segs[start:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches path segments, letting "**" consume zero or more of them
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+	if pattern[0] == "**" {
+		for skip := 0; skip <= len(segs); skip++ {
+			if matchSegments(pattern[1:], segs[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segs) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(pattern[0], segs[0]); !ok {
+		return false
+	}
+	return matchSegments(pattern[1:], segs[1:])
+}
+
+func splitPath(p string) []string {
+	var segs []string
+	for _, seg := range strings.Split(p, "/") {
+		if seg != "" && seg != "." {
+			segs = append(segs, seg)
+		}
+	}
+	return segs
+}
+
+// expandBraces expands "{a,b}" alternatives ("*.{ts,js}" -> "*.ts", "*.js")
+func expandBraces(pattern string) []string {
+	open := strings.IndexByte(pattern, '{')
+	if open < 0 {
+		return []string{pattern}
+	}
+	end := strings.IndexByte(pattern[open:], '}')
+	if end < 0 {
+		return []string{pattern}
+	}
+	end += open
+
+	var out []string
+	for _, alt := range strings.Split(pattern[open+1:end], ",") {
+		out = append(out, expandBraces(pattern[:open]+alt+pattern[end+1:])...)
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PromptArgument is an argument declared by a prompt document
+type PromptArgument struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description,omitempty"`
+	Required    bool   `yaml:"required" json:"required,omitempty"`
+}
+
+// promptFuncs declares the functions prompt templates may call.
+// The stubs let templates parse (e.g. during validation); callers pass the real implementations.
+var promptFuncs = template.FuncMap{
+	"document": func(id string) (string, error) { return "", nil }, // Body of another document
+}
+
+// PromptTemplate parses the body as a Go template.
+// funcs replaces the stub implementations of the prompt functions (nil keeps the stubs).
+func (d *Document) PromptTemplate(funcs template.FuncMap) (*template.Template, error) {
+	return template.New(d.ID).Funcs(promptFuncs).Funcs(funcs).Option("missingkey=zero").Parse(d.Body)
+}
+
+// RenderPrompt renders the body with the argument values, available to the template as {{.name}}.
+// Required arguments must be given; optional ones default to "".
+func (d *Document) RenderPrompt(args map[string]string, funcs template.FuncMap) (string, error) {
+	data := make(map[string]string, len(d.Arguments))
+	for _, arg := range d.Arguments {
+		value := args[arg.Name]
+		if arg.Required && strings.TrimSpace(value) == "" {
+			return "", fmt.Errorf("missing required argument %q", arg.Name)
+		}
+		data[arg.Name] = value
+	}
+	for name := range args {
+		if _, ok := data[name]; !ok {
+			return "", fmt.Errorf("unknown argument %q", name)
+		}
+	}
+
+	tmpl, err := d.PromptTemplate(funcs)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
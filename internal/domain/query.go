@@ -0,0 +1,45 @@
+package domain
+
+// Query fields usable as "field:value" filters
+const (
+	FieldAny         = ""            // Any text field (title, description, keywords, body)
+	FieldTitle       = "title"       // Title words
+	FieldDescription = "description" // Description words
+	FieldKeyword     = "keyword"     // Explicit keywords
+	FieldBody        = "body"        // Body text (requires full-text indexing)
+	FieldScope       = "scope"       // Exact scope name
+	FieldStatus      = "status"      // Document status
+	FieldSeverity    = "severity"    // Severity (documents without one count as "should")
+	FieldID          = "id"          // Exact document ID
+)
+
+// QueryNode is a node of a parsed search query
+type QueryNode interface {
+	queryNode()
+}
+
+// TermNode matches documents containing every term of Value in Field
+type TermNode struct {
+	Field string
+	Value string
+}
+
+// AndNode matches documents matching all children
+type AndNode struct {
+	Children []QueryNode
+}
+
+// OrNode matches documents matching any child
+type OrNode struct {
+	Children []QueryNode
+}
+
+// NotNode matches documents not matching the child
+type NotNode struct {
+	Child QueryNode
+}
+
+func (TermNode) queryNode() {}
+func (AndNode) queryNode()  {}
+func (OrNode) queryNode()   {}
+func (NotNode) queryNode()  {}
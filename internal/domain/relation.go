@@ -0,0 +1,51 @@
+package domain
+
+// Relation describes how one document relates to another
+type Relation string
+
+const (
+	RelationRelated      Relation = "related"
+	RelationSupersedes   Relation = "supersedes"
+	RelationSupersededBy Relation = "superseded by"
+	RelationRequires     Relation = "requires"
+	RelationRequiredBy   Relation = "required by"
+)
+
+// Link is a relation to another document by ID
+type Link struct {
+	Relation Relation
+	ID       string
+}
+
+// Links returns the relations declared in the document's frontmatter
+func (d *Document) Links() []Link {
+	var links []Link
+	for _, id := range d.Requires {
+		links = append(links, Link{Relation: RelationRequires, ID: id})
+	}
+	for _, id := range d.Supersedes {
+		links = append(links, Link{Relation: RelationSupersedes, ID: id})
+	}
+	if d.ReplacedBy != "" {
+		links = append(links, Link{Relation: RelationSupersededBy, ID: d.ReplacedBy})
+	}
+	for _, id := range d.Related {
+		links = append(links, Link{Relation: RelationRelated, ID: id})
+	}
+	return links
+}
+
+// Inverse returns the relation seen from the other document
+func (r Relation) Inverse() Relation {
+	switch r {
+	case RelationSupersedes:
+		return RelationSupersededBy
+	case RelationSupersededBy:
+		return RelationSupersedes
+	case RelationRequires:
+		return RelationRequiredBy
+	case RelationRequiredBy:
+		return RelationRequires
+	}
+	return r
+}
@@ -1,10 +1,49 @@
 package domain
 
+import "context"
+
 // DocumentRepository defines the interface for accessing documents.
+// Documents it hands out are shared and read-only; only GetByID returns one with its body.
 type DocumentRepository interface {
 	Load() error
 	GetAll() []*Document
 	GetErrors() []error
 	GetByID(id string) (*Document, bool)
-	Search(keywords []string, scopes []string, exactScopeMatch bool) []*Document
+	Search(query SearchQuery) []SearchResult
+}
+
+// ContextRepository is implemented by repositories whose lookups may fetch remote
+// content or call an embedding service; the context cancels that work.
+type ContextRepository interface {
+	GetByIDContext(ctx context.Context, id string) (*Document, bool)
+	SearchContext(ctx context.Context, query SearchQuery) []SearchResult
+}
+
+// GetByIDContext looks up a document, passing the context on when the repository supports it
+func GetByIDContext(ctx context.Context, repo DocumentRepository, id string) (*Document, bool) {
+	if r, ok := repo.(ContextRepository); ok {
+		return r.GetByIDContext(ctx, id)
+	}
+	return repo.GetByID(id)
+}
+
+// SearchContext searches, passing the context on when the repository supports it
+func SearchContext(ctx context.Context, repo DocumentRepository, query SearchQuery) []SearchResult {
+	if r, ok := repo.(ContextRepository); ok {
+		return r.SearchContext(ctx, query)
+	}
+	return repo.Search(query)
+}
+
+// SearchQuery describes a document search
+type SearchQuery struct {
+	Keywords        []string
+	Scopes          []string  // Scopes derived from the caller's context (e.g. file path)
+	FilePath        string    // File the caller is working on (matched against appliesTo)
+	ExactScopeMatch bool      // If true, keywords are treated as exact scope names
+	Expr            QueryNode // Parsed query expression (nil = OR over Keywords)
+
+	// Term matching overrides (nil = repository default)
+	Prefix *bool // Match indexed terms starting with a query term
+	Fuzzy  *bool // Match indexed terms within a small edit distance
 }
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScopeNode is a node of the scope hierarchy formed by the documents' scope paths
+// (e.g. coding/go/testing). The root node has no name.
+type ScopeNode struct {
+	Name      string
+	Parent    *ScopeNode
+	Children  map[string]*ScopeNode // Lower-cased name -> Child
+	Documents []*Document           // Documents whose scope path ends at this node
+}
+
+// NewScopeTree creates an empty scope hierarchy
+func NewScopeTree() *ScopeNode {
+	return &ScopeNode{Children: make(map[string]*ScopeNode)}
+}
+
+// BuildScopeTree creates the scope hierarchy of the documents
+func BuildScopeTree(docs []*Document) *ScopeNode {
+	root := NewScopeTree()
+	for _, doc := range docs {
+		root.Add(doc)
+	}
+	return root
+}
+
+// Add places the document at the node of its scope path, creating nodes as needed
+func (n *ScopeNode) Add(doc *Document) {
+	node := n
+	for _, scope := range doc.Scopes {
+		key := strings.ToLower(scope)
+		child, ok := node.Children[key]
+		if !ok {
+			child = &ScopeNode{Name: scope, Parent: node, Children: make(map[string]*ScopeNode)}
+			node.Children[key] = child
+		}
+		node = child
+	}
+	node.Documents = append(node.Documents, doc)
+}
+
+// Find returns the node of a scope path (nil if no document lives there or below)
+func (n *ScopeNode) Find(path []string) *ScopeNode {
+	node := n
+	for _, scope := range path {
+		node = node.Children[strings.ToLower(scope)]
+		if node == nil {
+			return nil
+		}
+	}
+	return node
+}
+
+// Walk visits the node and its descendants depth-first, children in name order
+func (n *ScopeNode) Walk(visit func(node *ScopeNode, depth int)) {
+	n.walk(visit, 0)
+}
+
+func (n *ScopeNode) walk(visit func(node *ScopeNode, depth int), depth int) {
+	visit(n, depth)
+	for _, child := range n.SortedChildren() {
+		child.walk(visit, depth+1)
+	}
+}
+
+// SortedChildren returns the children ordered by name
+func (n *ScopeNode) SortedChildren() []*ScopeNode {
+	children := make([]*ScopeNode, 0, len(n.Children))
+	for _, child := range n.Children {
+		children = append(children, child)
+	}
+	sort.Slice(children, func(a, b int) bool {
+		return strings.ToLower(children[a].Name) < strings.ToLower(children[b].Name)
+	})
+	return children
+}
+
+// Count returns the number of documents at this node and below
+func (n *ScopeNode) Count() int {
+	total := len(n.Documents)
+	for _, child := range n.Children {
+		total += child.Count()
+	}
+	return total
+}
+
+// Ancestors returns the names of every scope above the named scope, wherever it occurs in the tree
+func (n *ScopeNode) Ancestors(name string) []string {
+	key := strings.ToLower(name)
+	seen := make(map[string]struct{})
+	var ancestors []string
+	n.Walk(func(node *ScopeNode, _ int) {
+		if node.Parent == nil || strings.ToLower(node.Name) != key {
+			return
+		}
+		for p := node.Parent; p.Parent != nil; p = p.Parent {
+			lower := strings.ToLower(p.Name)
+			if _, ok := seen[lower]; !ok {
+				seen[lower] = struct{}{}
+				ancestors = append(ancestors, lower)
+			}
+		}
+	})
+	return ancestors
+}
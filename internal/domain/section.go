@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// SectionSeparator joins a document ID and a section slug (e.g. coding.go.errors#wrapping)
+const SectionSeparator = "#"
+
+// Section is a heading of a document body and the content below it,
+// up to the next heading of the same or a higher level
+type Section struct {
+	Slug  string // Stable anchor derived from the heading text (e.g. "error-wrapping")
+	Title string // Heading text
+	Level int    // 1 for "#", 2 for "##", ...
+	Start int    // Byte offset of the heading line in the body
+	End   int    // Byte offset where the section (including its subsections) ends
+}
+
+// SectionID returns the ID addressing a section of a document (e.g. coding.go.errors#wrapping)
+func SectionID(docID, slug string) string {
+	return docID + SectionSeparator + slug
+}
+
+// SplitSectionID splits "id#slug" into the document ID and the slug ("" if absent)
+func SplitSectionID(id string) (string, string) {
+	docID, slug, _ := strings.Cut(id, SectionSeparator)
+	return docID, slug
+}
+
+// Content returns the section's heading and content from the body it was parsed from
+func (s Section) Content(body string) string {
+	return body[s.Start:s.End]
+}
+
+// EstimatedTokens approximates the token count of the section (about 4 bytes per token)
+func (s Section) EstimatedTokens() int {
+	return (s.End - s.Start + 3) / 4
+}
+
+// Sections parses the headings of the body in document order.
+// Slugs follow GitHub's anchors: lowercased, punctuation dropped, spaces turned into
+// hyphens, and repeated headings suffixed with -1, -2, ...
+func (d *Document) Sections() []Section {
+	return ParseSections(d.Body)
+}
+
+// FindSection returns the section with the slug (case-insensitive)
+func FindSection(sections []Section, slug string) (Section, bool) {
+	for _, s := range sections {
+		if strings.EqualFold(s.Slug, slug) {
+			return s, true
+		}
+	}
+	return Section{}, false
+}
+
+// SectionAt returns the innermost section containing the byte offset
+func SectionAt(sections []Section, offset int) (Section, bool) {
+	var found Section
+	ok := false
+	for _, s := range sections {
+		if s.Start > offset {
+			break
+		}
+		if offset < s.End {
+			found, ok = s, true
+		}
+	}
+	return found, ok
+}
+
+// ParseSections parses ATX headings ("## Title"), skipping fenced code blocks
+func ParseSections(body string) []Section {
+	var sections []Section
+	slugs := make(map[string]int)
+	fence := ""
+
+	for offset := 0; offset < len(body); {
+		line := body[offset:]
+		next := len(body)
+		if i := strings.IndexByte(line, '\n'); i >= 0 {
+			line = line[:i]
+			next = offset + i + 1
+		}
+
+		trimmed := strings.TrimLeft(line, " ")
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			fence = "```"
+		case strings.HasPrefix(trimmed, "~~~"):
+			fence = "~~~"
+		case len(line)-len(trimmed) <= 3:
+			if level, title, ok := parseHeading(trimmed); ok {
+				slug := slugify(title)
+				if n, dup := slugs[slug]; dup {
+					slugs[slug] = n + 1
+					slug = fmt.Sprintf("%s-%d", slug, n+1)
+				} else {
+					slugs[slug] = 0
+				}
+				sections = append(sections, Section{Slug: slug, Title: title, Level: level, Start: offset})
+			}
+		}
+		offset = next
+	}
+
+	// A section ends where the next heading of the same or a higher level starts
+	for a := range sections {
+		sections[a].End = len(body)
+		for b := a + 1; b < len(sections); b++ {
+			if sections[b].Level <= sections[a].Level {
+				sections[a].End = sections[b].Start
+				break
+			}
+		}
+	}
+	return sections
+}
+
+// parseHeading parses "## Title ##" into its level and text
+func parseHeading(line string) (int, string, bool) {
+	level := 0
+	for level < len(line) && line[level] == '#' {
+		level++
+	}
+	if level == 0 || level > 6 {
+		return 0, "", false
+	}
+	rest := line[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "", false
+	}
+	title := strings.TrimSpace(strings.TrimRight(strings.TrimSpace(rest), "#"))
+	if title == "" {
+		return 0, "", false
+	}
+	return level, title, true
+}
+
+// slugify turns heading text into an anchor ("Error Wrapping (Go 1.13+)" -> "error-wrapping-go-113")
+func slugify(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_':
+			b.WriteRune(r)
+		case r == ' ':
+			b.WriteRune('-')
+		}
+	}
+	return b.String()
+}
@@ -14,6 +14,76 @@ type Config struct {
 	RemoteToken string       `yaml:"remoteToken,omitempty"`
 	Update      UpdateConfig `yaml:"update"`
 	Logging     Logging      `yaml:"logging,omitempty"`
+	Search      SearchConfig `yaml:"search,omitempty"`
+	Synonyms    Synonyms     `yaml:"synonyms,omitempty"`
+	// Namespaces prefixes the document IDs of a reference (keyed as written in references),
+	// e.g. "https://example.com/docs/": org serves "org:coding.go.naming"
+	Namespaces map[string]string `yaml:"namespaces,omitempty"`
+	// ScopeMapping maps the caller's file path to search scopes (checked before the built-in defaults)
+	ScopeMapping []ScopeMappingRule `yaml:"scopeMapping,omitempty"`
+	// Waivers exempt the project from referenced guidelines
+	Waivers []Waiver `yaml:"waivers,omitempty"`
+}
+
+// Waiver exempts the project from a guideline
+type Waiver struct {
+	ID     string `yaml:"id"`
+	Reason string `yaml:"reason"` // Required; shown to agents reading the guideline
+}
+
+// ScopeMappingRule assigns scopes to file paths matching a glob pattern
+type ScopeMappingRule struct {
+	// Pattern is a glob on the file path ("*", "?" and "**" for any number of directories),
+	// e.g. "services/payments/**" or "*.py"
+	Pattern string `yaml:"pattern"`
+	// Scopes are passed to the search when the pattern matches
+	Scopes []string `yaml:"scopes"`
+}
+
+// Synonyms maps a canonical term to its aliases (e.g. typescript: [ts])
+type Synonyms map[string][]string
+
+// SearchConfig tunes how search_documents ranks results
+type SearchConfig struct {
+	// FullText enables indexing of document bodies (headings, paragraphs and code)
+	FullText bool `yaml:"fullText,omitempty"`
+	// Prefix makes keywords match terms starting with them by default (e.g. "test" -> "testing")
+	Prefix bool `yaml:"prefix,omitempty"`
+	// Fuzzy makes keywords tolerate typos by default
+	Fuzzy bool `yaml:"fuzzy,omitempty"`
+	// MaxEdits bounds the edit distance of fuzzy matches (default: 2)
+	MaxEdits int `yaml:"maxEdits,omitempty"`
+	// Language selects stemming and stopwords: "english" (default) or "none"
+	Language string `yaml:"language,omitempty"`
+	// Stopwords are additional words ignored in titles, descriptions and bodies
+	Stopwords []string `yaml:"stopwords,omitempty"`
+	// Weights overrides the per-field ranking boosts (title, description, keywords, scope, body)
+	Weights map[string]float64 `yaml:"weights,omitempty"`
+	// SeverityWeights overrides the score multipliers per severity (must, should, may)
+	SeverityWeights map[string]float64 `yaml:"severityWeights,omitempty"`
+	// ScopeMode is "strict" (default: a document's scopes must all be in the context)
+	// or "inherit" (scopes imply their ancestors and nested documents match)
+	ScopeMode string `yaml:"scopeMode,omitempty"`
+	// Semantic enables vector search blended with keyword ranking
+	Semantic SemanticConfig `yaml:"semantic,omitempty"`
+}
+
+// SemanticConfig selects the embedding provider for semantic search
+type SemanticConfig struct {
+	// Provider is "http" (OpenAI/Ollama-compatible endpoint) or "hashed" (built-in TF-IDF); empty disables semantic search
+	Provider string `yaml:"provider,omitempty"`
+	// URL of the embeddings endpoint (http only), e.g. http://localhost:11434/v1/embeddings
+	URL string `yaml:"url,omitempty"`
+	// Model name sent to the endpoint (http only)
+	Model string `yaml:"model,omitempty"`
+	// APIKey is sent as a Bearer token (http only). KEX_EMBEDDINGS_API_KEY takes precedence.
+	APIKey string `yaml:"apiKey,omitempty"`
+	// Dimensions of hashed vectors (hashed only, default: 512)
+	Dimensions int `yaml:"dimensions,omitempty"`
+	// Weight is the share of vector similarity in the final score, 0-1 (default: 0.5)
+	Weight float64 `yaml:"weight,omitempty"`
+	// MinSimilarity is the similarity a document needs to match without keywords (default: 0.3)
+	MinSimilarity float64 `yaml:"minSimilarity,omitempty"`
 }
 
 type Logging struct {
@@ -0,0 +1,135 @@
+package embedding
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// queryCacheSize bounds the query vectors kept in memory
+const queryCacheSize = 256
+
+// CachedEmbedder stores document vectors on disk, keyed by a hash of the model name and text,
+// so unchanged documents are not re-embedded on every start.
+// Query vectors are only kept in a bounded in-memory cache.
+type CachedEmbedder struct {
+	Embedder Embedder
+	Dir      string
+
+	mu      sync.Mutex
+	queries *list.List               // Recently embedded queries, most recent first
+	byQuery map[string]*list.Element // Query text -> Element holding a cachedQuery
+}
+
+type cachedQuery struct {
+	text   string
+	vector []float32
+}
+
+// NewCachedEmbedder wraps an embedder with a cache in dir
+func NewCachedEmbedder(embedder Embedder, dir string) *CachedEmbedder {
+	return &CachedEmbedder{Embedder: embedder, Dir: dir, queries: list.New(), byQuery: make(map[string]*list.Element)}
+}
+
+func (c *CachedEmbedder) Name() string {
+	return c.Embedder.Name()
+}
+
+// Embed returns cached vectors and embeds only the texts missing from the cache.
+// Cache write failures are ignored; they only cost a recomputation.
+func (c *CachedEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	var missing []string
+	var missingIdx []int
+
+	for n, text := range texts {
+		if v, ok := c.read(text); ok {
+			vectors[n] = v
+			continue
+		}
+		missing = append(missing, text)
+		missingIdx = append(missingIdx, n)
+	}
+
+	if len(missing) == 0 {
+		return vectors, nil
+	}
+
+	embedded, err := c.Embedder.Embed(ctx, missing)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range embedded {
+		vectors[missingIdx[k]] = v
+		c.write(missing[k], v)
+	}
+	return vectors, nil
+}
+
+// EmbedQuery returns the vector of a search query, from the in-memory cache when recently embedded.
+// Queries are never written to disk.
+func (c *CachedEmbedder) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
+	c.mu.Lock()
+	if e, ok := c.byQuery[text]; ok {
+		c.queries.MoveToFront(e)
+		c.mu.Unlock()
+		return e.Value.(*cachedQuery).vector, nil
+	}
+	c.mu.Unlock()
+
+	vectors, err := c.Embedder.Embed(ctx, []string{text})
+	if err != nil {
+		return nil, err
+	}
+	if len(vectors) == 0 {
+		return nil, fmt.Errorf("no vector returned for the query")
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.byQuery[text]; !ok {
+		c.byQuery[text] = c.queries.PushFront(&cachedQuery{text: text, vector: vectors[0]})
+		if c.queries.Len() > queryCacheSize {
+			oldest := c.queries.Back()
+			c.queries.Remove(oldest)
+			delete(c.byQuery, oldest.Value.(*cachedQuery).text)
+		}
+	}
+	return vectors[0], nil
+}
+
+func (c *CachedEmbedder) path(text string) string {
+	sum := sha256.Sum256([]byte(c.Name() + "\x00" + text))
+	key := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Dir, key[:2], key+".json")
+}
+
+func (c *CachedEmbedder) read(text string) ([]float32, bool) {
+	data, err := os.ReadFile(c.path(text))
+	if err != nil {
+		return nil, false
+	}
+	var v []float32
+	if err := json.Unmarshal(data, &v); err != nil || len(v) == 0 {
+		return nil, false
+	}
+	return v, true
+}
+
+func (c *CachedEmbedder) write(text string, v []float32) {
+	path := c.path(text)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0644)
+}
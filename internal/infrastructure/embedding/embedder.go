@@ -0,0 +1,62 @@
+package embedding
+
+import (
+	"context"
+	"math"
+)
+
+// Embedder converts texts into vectors whose cosine similarity reflects semantic similarity
+type Embedder interface {
+	// Name identifies the model; vectors from embedders with different names are not comparable
+	Name() string
+	// Embed returns one vector per text, in order; the context cancels a remote call
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// CorpusEmbedder is an Embedder whose vectors depend on the indexed corpus
+// (e.g. term weights learned from it). Such vectors must not be precomputed.
+type CorpusEmbedder interface {
+	Embedder
+	// Fit learns corpus statistics; it must be called before Embed
+	Fit(texts []string)
+}
+
+// QueryEmbedder is an Embedder that handles search queries apart from documents
+// (e.g. caching them in memory only)
+type QueryEmbedder interface {
+	Embedder
+	// EmbedQuery returns the vector of a search query
+	EmbedQuery(ctx context.Context, text string) ([]float32, error)
+}
+
+// Cosine returns the cosine similarity of two vectors (0 if their sizes differ or either is zero)
+func Cosine(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(na) * math.Sqrt(nb))
+}
+
+// normalize scales the vector to unit length in place
+func normalize(v []float32) {
+	var norm float64
+	for _, x := range v {
+		norm += float64(x) * float64(x)
+	}
+	if norm == 0 {
+		return
+	}
+	scale := float32(1 / math.Sqrt(norm))
+	for i := range v {
+		v[i] *= scale
+	}
+}
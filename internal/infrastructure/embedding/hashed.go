@@ -0,0 +1,91 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// DefaultDimensions is the vector size of the hashed embedder
+const DefaultDimensions = 512
+
+// HashedEmbedder is a pure-Go fallback that needs no model: terms are weighted
+// with TF-IDF and folded into a fixed-size vector with the hashing trick.
+// It only captures shared terms (including stems and synonyms resolved by
+// the tokenizer), not meaning.
+type HashedEmbedder struct {
+	Dimensions int
+	Tokenize   func(text string) []string
+
+	docFreq map[string]int // Term -> Number of fitted texts containing it
+	docs    int
+}
+
+// NewHashedEmbedder creates a HashedEmbedder using the given tokenizer
+func NewHashedEmbedder(dimensions int, tokenize func(text string) []string) *HashedEmbedder {
+	if dimensions <= 0 {
+		dimensions = DefaultDimensions
+	}
+	return &HashedEmbedder{Dimensions: dimensions, Tokenize: tokenize}
+}
+
+func (e *HashedEmbedder) Name() string {
+	return fmt.Sprintf("hashed-tfidf:%d", e.Dimensions)
+}
+
+// Fit counts document frequencies so that rare terms weigh more than common ones
+func (e *HashedEmbedder) Fit(texts []string) {
+	e.docFreq = make(map[string]int)
+	e.docs = len(texts)
+	for _, text := range texts {
+		seen := make(map[string]struct{})
+		for _, term := range e.Tokenize(text) {
+			if _, ok := seen[term]; ok {
+				continue
+			}
+			seen[term] = struct{}{}
+			e.docFreq[term]++
+		}
+	}
+}
+
+func (e *HashedEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	vectors := make([][]float32, len(texts))
+	for n, text := range texts {
+		vectors[n] = e.embed(text)
+	}
+	return vectors, nil
+}
+
+func (e *HashedEmbedder) embed(text string) []float32 {
+	tf := make(map[string]int)
+	for _, term := range e.Tokenize(text) {
+		tf[term]++
+	}
+
+	v := make([]float32, e.Dimensions)
+	for term, count := range tf {
+		h := fnv.New64a()
+		h.Write([]byte(term))
+		sum := h.Sum64()
+
+		// The sign bit spreads collisions around zero instead of accumulating them
+		sign := float32(1)
+		if sum>>63 == 1 {
+			sign = -1
+		}
+		weight := (1 + math.Log(float64(count))) * e.idf(term)
+		v[sum%uint64(e.Dimensions)] += sign * float32(weight)
+	}
+	normalize(v)
+	return v
+}
+
+// idf uses smoothed inverse document frequency; unfitted embedders weigh all terms equally
+func (e *HashedEmbedder) idf(term string) float64 {
+	if e.docs == 0 {
+		return 1
+	}
+	return math.Log(float64(1+e.docs)/float64(1+e.docFreq[term])) + 1
+}
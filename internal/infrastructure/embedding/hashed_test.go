@@ -0,0 +1,108 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestHashedEmbedder(t *testing.T) {
+	e := NewHashedEmbedder(0, strings.Fields)
+	corpus := []string{
+		"retry idempotent network calls",
+		"naming conventions for packages",
+		"network timeouts and retry budgets",
+	}
+	e.Fit(corpus)
+
+	vectors, err := e.Embed(context.Background(), append(corpus, "retry"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vectors[0]) != DefaultDimensions {
+		t.Fatalf("expected %d dimensions, got %d", DefaultDimensions, len(vectors[0]))
+	}
+
+	query := vectors[3]
+	related, unrelated := Cosine(query, vectors[0]), Cosine(query, vectors[1])
+	if related <= unrelated {
+		t.Errorf("expected shared terms to be more similar: related=%f unrelated=%f", related, unrelated)
+	}
+	if got := Cosine(vectors[0], vectors[0]); got < 0.999 {
+		t.Errorf("expected unit self-similarity, got %f", got)
+	}
+}
+
+// countingEmbedder records how many texts it was asked to embed
+type countingEmbedder struct {
+	calls int
+}
+
+func (c *countingEmbedder) Name() string { return "counting" }
+
+func (c *countingEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	c.calls += len(texts)
+	vectors := make([][]float32, len(texts))
+	for n, text := range texts {
+		vectors[n] = []float32{float32(len(text)), 1}
+	}
+	return vectors, nil
+}
+
+func TestCachedEmbedder(t *testing.T) {
+	inner := &countingEmbedder{}
+	c := NewCachedEmbedder(inner, t.TempDir())
+
+	first, err := c.Embed(context.Background(), []string{"alpha", "beta"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := c.Embed(context.Background(), []string{"beta", "gamma", "alpha"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if inner.calls != 3 {
+		t.Errorf("expected 3 texts embedded (cache hits skipped), got %d", inner.calls)
+	}
+	if second[0][0] != first[1][0] || second[2][0] != first[0][0] {
+		t.Errorf("cached vectors differ: first=%v second=%v", first, second)
+	}
+}
+
+func TestCachedEmbedder_EmbedQuery(t *testing.T) {
+	inner := &countingEmbedder{}
+	dir := t.TempDir()
+	c := NewCachedEmbedder(inner, dir)
+
+	t.Run("it should cache queries in memory only", func(t *testing.T) {
+		if _, err := c.EmbedQuery(context.Background(), "alpha"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.EmbedQuery(context.Background(), "alpha"); err != nil {
+			t.Fatal(err)
+		}
+		if inner.calls != 1 {
+			t.Errorf("expected 1 text embedded, got %d", inner.calls)
+		}
+		if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+			t.Errorf("expected no query vector on disk, got %d entries", len(entries))
+		}
+	})
+
+	t.Run("it should evict the least recently used queries", func(t *testing.T) {
+		for n := 0; n <= queryCacheSize; n++ {
+			if _, err := c.EmbedQuery(context.Background(), fmt.Sprintf("query %d", n)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if got := c.queries.Len(); got != queryCacheSize {
+			t.Errorf("expected %d cached queries, got %d", queryCacheSize, got)
+		}
+		if _, ok := c.byQuery["alpha"]; ok {
+			t.Error("expected the oldest query to be evicted")
+		}
+	})
+}
@@ -0,0 +1,103 @@
+package embedding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// HTTPEmbedder calls an OpenAI-compatible embeddings endpoint.
+// Ollama is supported through both its OpenAI-compatible (/v1/embeddings)
+// and native (/api/embed) endpoints.
+type HTTPEmbedder struct {
+	URL    string // e.g. http://localhost:11434/v1/embeddings
+	Model  string
+	APIKey string // Sent as a Bearer token when set
+	Client *http.Client
+}
+
+// NewHTTPEmbedder creates an HTTPEmbedder with a default timeout
+func NewHTTPEmbedder(url, model, apiKey string) *HTTPEmbedder {
+	return &HTTPEmbedder{
+		URL:    url,
+		Model:  model,
+		APIKey: apiKey,
+		Client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+func (e *HTTPEmbedder) Name() string {
+	return "http:" + e.Model
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	// OpenAI-compatible
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+	// Ollama native
+	Embeddings [][]float32 `json:"embeddings"`
+}
+
+func (e *HTTPEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	if len(texts) == 0 {
+		return nil, nil
+	}
+
+	body, err := json.Marshal(embeddingRequest{Model: e.Model, Input: texts})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if e.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+e.APIKey)
+	}
+
+	resp, err := e.Client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("embeddings request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("embeddings request failed: status %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var parsed embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("failed to decode embeddings: %w", err)
+	}
+
+	vectors := parsed.Embeddings
+	if len(parsed.Data) > 0 {
+		vectors = make([][]float32, len(parsed.Data))
+		for i, d := range parsed.Data {
+			if d.Index >= 0 && d.Index < len(vectors) {
+				vectors[d.Index] = d.Embedding
+			} else {
+				vectors[i] = d.Embedding
+			}
+		}
+	}
+
+	if len(vectors) != len(texts) {
+		return nil, fmt.Errorf("embeddings endpoint returned %d vectors for %d texts", len(vectors), len(texts))
+	}
+	return vectors, nil
+}
@@ -0,0 +1,84 @@
+package embedding
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestHTTPEmbedder_Embed(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     [][]float32
+	}{
+		{
+			name:     "it should read OpenAI-compatible responses in index order",
+			response: `{"data":[{"index":1,"embedding":[0,1]},{"index":0,"embedding":[1,0]}]}`,
+			want:     [][]float32{{1, 0}, {0, 1}},
+		},
+		{
+			name:     "it should read Ollama native responses",
+			response: `{"embeddings":[[1,0],[0,1]]}`,
+			want:     [][]float32{{1, 0}, {0, 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+					t.Errorf("Authorization = %q, want Bearer secret", got)
+				}
+				var req embeddingRequest
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					t.Fatal(err)
+				}
+				if req.Model != "nomic-embed-text" || len(req.Input) != 2 {
+					t.Errorf("unexpected request: %+v", req)
+				}
+				w.Write([]byte(tt.response))
+			}))
+			defer srv.Close()
+
+			e := NewHTTPEmbedder(srv.URL, "nomic-embed-text", "secret")
+			got, err := e.Embed(context.Background(), []string{"a", "b"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Embed() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("it should report endpoint errors", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "model not found", http.StatusNotFound)
+		}))
+		defer srv.Close()
+
+		if _, err := NewHTTPEmbedder(srv.URL, "missing", "").Embed(context.Background(), []string{"a"}); err == nil {
+			t.Error("expected an error")
+		}
+	})
+
+	t.Run("it should abort the request when the context is cancelled", func(t *testing.T) {
+		release := make(chan struct{})
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			<-release
+		}))
+		defer srv.Close()
+		defer close(release)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := NewHTTPEmbedder(srv.URL, "slow", "").Embed(ctx, []string{"a"}); !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
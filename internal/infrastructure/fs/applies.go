@@ -0,0 +1,35 @@
+package fs
+
+import (
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// appliesToBoost is added to the score of documents whose appliesTo matches the caller's file
+const appliesToBoost = 1.0
+
+// matchedAppliesTo is reported as MatchedField when only the file pattern matched
+const matchedAppliesTo = "appliesTo"
+
+// fileTargets returns the documents targeting the caller's file (ID -> first matching pattern)
+func (i *Indexer) fileTargets(filePath string) map[string]string {
+	targets := make(map[string]string)
+	if filePath == "" {
+		return targets
+	}
+	for id, doc := range i.Documents {
+		if pattern := doc.AppliesToPath(filePath); pattern != "" {
+			targets[id] = pattern
+		}
+	}
+	return targets
+}
+
+// boostTarget ranks a document targeting the caller's file higher.
+// Without a matching term, the pattern itself is shown as the match.
+func boostTarget(result *domain.SearchResult, pattern string) {
+	result.Score += appliesToBoost
+	if result.MatchedField == "" {
+		result.MatchedField = matchedAppliesTo
+		result.Snippet = pattern
+	}
+}
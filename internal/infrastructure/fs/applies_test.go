@@ -0,0 +1,84 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Search_AppliesTo(t *testing.T) {
+	idx := New(&MockProvider{Documents: []*DocumentSchema{
+		{ID: "testing.go-tests", Title: "Table Driven Tests", Keywords: []string{"tests"}, Scopes: []string{"testing"}, AppliesTo: []string{"**/*_test.go"}},
+		{ID: "go-errors", Title: "Error Handling", Keywords: []string{"errors"}},
+		{ID: "migrations", Title: "Migration Rules", Keywords: []string{"schema"}, AppliesTo: []string{"migrations/**"}},
+	}}, &logger.NoOpLogger{})
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	t.Run("it should include documents targeting the file even without a keyword match", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Keywords: []string{"errors"}, FilePath: "/repo/pkg/parser_test.go"})
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results, got %v", ids(results))
+		}
+		for _, r := range results {
+			if r.ID != "testing.go-tests" {
+				continue
+			}
+			if r.MatchedField != "appliesTo" || r.Snippet != "**/*_test.go" || r.Score <= 0 {
+				t.Errorf("expected a scored pattern match, got %s: %q (%.2f)", r.MatchedField, r.Snippet, r.Score)
+			}
+			return
+		}
+		t.Errorf("expected testing.go-tests in %v", ids(results))
+	})
+
+	t.Run("it should not include targeted documents for other files", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Keywords: []string{"errors"}, FilePath: "pkg/parser.go"})
+		if got, want := ids(results), []string{"go-errors"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("it should still require query expressions to match", func(t *testing.T) {
+		expr := domain.TermNode{Value: "schema"}
+		results := idx.Search(domain.SearchQuery{Expr: expr, FilePath: "pkg/parser_test.go"})
+		if got, want := ids(results), []string{"migrations"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestLocalProvider_AppliesTo(t *testing.T) {
+	root := t.TempDir()
+	content := "---\ntitle: Migration Rules\nappliesTo: [\"migrations/**\", \"*.sql\"]\n---\nbody\n"
+	if err := os.WriteFile(filepath.Join(root, "migrations.md"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx := New(NewLocalProvider(root, &logger.NoOpLogger{}), &logger.NoOpLogger{})
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := idx.Export()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"migrations/**", "*.sql"}
+	if len(schema.Documents) != 1 || !reflect.DeepEqual(schema.Documents[0].AppliesTo, want) {
+		t.Errorf("expected appliesTo %v to be exported, got %+v", want, schema.Documents)
+	}
+}
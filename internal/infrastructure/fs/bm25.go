@@ -0,0 +1,174 @@
+package fs
+
+import (
+	"math"
+	"sort"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// Field identifies a searchable part of a document
+type Field string
+
+const (
+	FieldTitle       Field = "title"
+	FieldDescription Field = "description"
+	FieldKeywords    Field = "keywords"
+	FieldScope       Field = "scope"
+	FieldBody        Field = "body"
+)
+
+// DefaultFieldWeights are the per-field boosts used when none are configured.
+// Keywords and titles are curated by authors, so they outweigh free-form descriptions.
+var DefaultFieldWeights = map[Field]float64{
+	FieldTitle:       3.0,
+	FieldDescription: 1.0,
+	FieldKeywords:    2.5,
+	FieldScope:       1.5,
+	FieldBody:        0.5,
+}
+
+// BM25 tuning parameters (standard defaults)
+const (
+	bm25K1 = 1.2
+	bm25B  = 0.75
+)
+
+// posting holds the per-field term frequencies of a term within one document
+type posting struct {
+	doc   *domain.Document
+	freqs map[Field]int
+	spans map[Field][]span // Where the term occurs in the field text (for snippets)
+}
+
+// span is the byte range of a term occurrence in the source text of a field
+type span struct {
+	start, end int
+}
+
+// bm25Index is an inverted index scored with BM25F (BM25 over weighted fields)
+type bm25Index struct {
+	weights      map[Field]float64
+	postings     map[string]map[string]*posting // Term -> DocID -> Posting
+	fieldLengths map[string]map[Field]int       // DocID -> Field -> Term count
+	totalLengths map[Field]int                  // Field -> Sum of term counts
+}
+
+func newBM25Index(weights map[Field]float64) *bm25Index {
+	if len(weights) == 0 {
+		weights = DefaultFieldWeights
+	}
+	return &bm25Index{
+		weights:      weights,
+		postings:     make(map[string]map[string]*posting),
+		fieldLengths: make(map[string]map[Field]int),
+		totalLengths: make(map[Field]int),
+	}
+}
+
+// addTokens indexes tokens of a field's source text, recording where each occurred
+func (b *bm25Index) addTokens(doc *domain.Document, field Field, tokens []Token) {
+	b.add(doc, field, tokenTerms(tokens))
+	for _, t := range tokens {
+		if p, ok := b.postings[t.Term][doc.ID]; ok {
+			if p.spans == nil {
+				p.spans = make(map[Field][]span)
+			}
+			p.spans[field] = append(p.spans[field], span{start: t.Start, end: t.End})
+		}
+	}
+}
+
+// spans returns the occurrences of the terms in a field of the document, in text order
+func (b *bm25Index) spans(docID string, field Field, terms []queryTerm) []span {
+	var spans []span
+	seen := make(map[string]struct{}, len(terms))
+	for _, qt := range terms {
+		if _, ok := seen[qt.term]; ok {
+			continue
+		}
+		seen[qt.term] = struct{}{}
+		if p, ok := b.postings[qt.term][docID]; ok {
+			spans = append(spans, p.spans[field]...)
+		}
+	}
+	sort.Slice(spans, func(a, b int) bool { return spans[a].start < spans[b].start })
+	return spans
+}
+
+// add indexes the given terms under a field of the document
+func (b *bm25Index) add(doc *domain.Document, field Field, terms []string) {
+	lengths, ok := b.fieldLengths[doc.ID]
+	if !ok {
+		lengths = make(map[Field]int)
+		b.fieldLengths[doc.ID] = lengths
+	}
+
+	for _, term := range terms {
+		if term == "" {
+			continue
+		}
+		docs, ok := b.postings[term]
+		if !ok {
+			docs = make(map[string]*posting)
+			b.postings[term] = docs
+		}
+		p, ok := docs[doc.ID]
+		if !ok {
+			p = &posting{doc: doc, freqs: make(map[Field]int)}
+			docs[doc.ID] = p
+		}
+		p.freqs[field]++
+		lengths[field]++
+		b.totalLengths[field]++
+	}
+}
+
+// scoreTerms returns the BM25F relevance of the document for expanded query terms,
+// scaling each term's contribution by its boost. Terms are normalized the same way as indexed terms.
+func (b *bm25Index) scoreTerms(docID string, terms []queryTerm) float64 {
+	var total float64
+	seen := make(map[string]struct{}, len(terms))
+	for _, qt := range terms {
+		if _, ok := seen[qt.term]; ok {
+			continue
+		}
+		seen[qt.term] = struct{}{}
+
+		p, ok := b.postings[qt.term][docID]
+		if !ok {
+			continue
+		}
+		tf := b.weightedFrequency(docID, p)
+		total += qt.boost * b.idf(qt.term) * tf * (bm25K1 + 1) / (bm25K1 + tf)
+	}
+	return total
+}
+
+// weightedFrequency combines the length-normalized field frequencies of a posting
+func (b *bm25Index) weightedFrequency(docID string, p *posting) float64 {
+	var tf float64
+	for field, freq := range p.freqs {
+		avg := b.averageLength(field)
+		if avg == 0 {
+			continue
+		}
+		norm := 1 - bm25B + bm25B*float64(b.fieldLengths[docID][field])/avg
+		tf += b.weights[field] * float64(freq) / norm
+	}
+	return tf
+}
+
+func (b *bm25Index) averageLength(field Field) float64 {
+	if len(b.fieldLengths) == 0 {
+		return 0
+	}
+	return float64(b.totalLengths[field]) / float64(len(b.fieldLengths))
+}
+
+// idf uses the BM25 "plus one" variant so that common terms never score negatively
+func (b *bm25Index) idf(term string) float64 {
+	n := float64(len(b.postings[term]))
+	total := float64(len(b.fieldLengths))
+	return math.Log(1 + (total-n+0.5)/(n+0.5))
+}
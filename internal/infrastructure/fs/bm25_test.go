@@ -0,0 +1,64 @@
+package fs
+
+import (
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// exact weights query terms as exact matches
+func exact(terms []string) []queryTerm {
+	weighted := make([]queryTerm, 0, len(terms))
+	for _, t := range terms {
+		weighted = append(weighted, queryTerm{term: t, boost: exactBoost})
+	}
+	return weighted
+}
+
+func TestBM25Index_Score(t *testing.T) {
+	many := &domain.Document{ID: "many"}
+	single := &domain.Document{ID: "single"}
+	other := &domain.Document{ID: "other"}
+
+	idx := newBM25Index(nil)
+	idx.add(many, FieldKeywords, []string{"error", "handling", "wrap", "go", "context"})
+	idx.add(many, FieldDescription, []string{"how", "to", "wrap", "errors"})
+	idx.add(single, FieldKeywords, []string{"naming"})
+	idx.add(single, FieldDescription, []string{"error", "messages", "should", "be", "lowercase"})
+	idx.add(other, FieldKeywords, []string{"logging"})
+
+	t.Run("it should rank documents matching more terms higher", func(t *testing.T) {
+		query := []string{"error", "handling", "wrap", "context"}
+		if idx.scoreTerms("many", exact(query)) <= idx.scoreTerms("single", exact(query)) {
+			t.Errorf("expected 'many' (%f) to outrank 'single' (%f)", idx.scoreTerms("many", exact(query)), idx.scoreTerms("single", exact(query)))
+		}
+	})
+
+	t.Run("it should weight keyword matches above description matches", func(t *testing.T) {
+		kw := &domain.Document{ID: "kw"}
+		desc := &domain.Document{ID: "desc"}
+		weighted := newBM25Index(nil)
+		weighted.add(kw, FieldKeywords, []string{"testing"})
+		weighted.add(kw, FieldDescription, []string{"unrelated"})
+		weighted.add(desc, FieldKeywords, []string{"unrelated"})
+		weighted.add(desc, FieldDescription, []string{"testing"})
+
+		if weighted.scoreTerms("kw", exact([]string{"testing"})) <= weighted.scoreTerms("desc", exact([]string{"testing"})) {
+			t.Error("expected keyword match to outrank description match")
+		}
+	})
+
+	t.Run("it should score zero for documents without matching terms", func(t *testing.T) {
+		if got := idx.scoreTerms("other", exact([]string{"error"})); got != 0 {
+			t.Errorf("expected score 0, got %f", got)
+		}
+	})
+
+	t.Run("it should not count duplicated query terms twice", func(t *testing.T) {
+		once := idx.scoreTerms("single", exact([]string{"error"}))
+		twice := idx.scoreTerms("single", exact([]string{"error", "error"}))
+		if once != twice {
+			t.Errorf("expected %f, got %f", once, twice)
+		}
+	})
+}
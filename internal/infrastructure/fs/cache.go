@@ -0,0 +1,158 @@
+package fs
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// CacheDir returns the cache directory of a project (.kex/cache)
+func CacheDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".kex", "cache")
+}
+
+// cacheVersion invalidates caches written by an incompatible version of kex
+const cacheVersion = 2
+
+// localCache persists the parsed documents of a local source between runs.
+// Entries are reused while a file's mtime and size are unchanged; otherwise the
+// content hash decides whether the file has to be parsed again.
+type localCache struct {
+	Version int                         `json:"version"`
+	Root    string                      `json:"root"`
+	Files   map[string]*localCacheEntry `json:"files"` // Relative path -> Entry
+
+	path  string
+	next  map[string]*localCacheEntry // Entries seen in this load (deleted files drop out)
+	dirty bool
+}
+
+type localCacheEntry struct {
+	ModTime  int64           `json:"modTime"` // Unix nanoseconds
+	Size     int64           `json:"size"`
+	Hash     string          `json:"hash"` // SHA-256 of the file content
+	Document *DocumentSchema `json:"document,omitempty"`
+	Error    string          `json:"error,omitempty"` // Parse error, reported again on every load
+}
+
+// openLocalCache reads the cache of a source root; a missing or outdated cache starts empty
+func openLocalCache(cacheDir, root string) *localCache {
+	c := &localCache{
+		path: filepath.Join(cacheDir, "local-"+cacheKey(root)+".json"),
+		next: make(map[string]*localCacheEntry),
+	}
+	if data, err := os.ReadFile(c.path); err == nil {
+		if json.Unmarshal(data, c) != nil || c.Version != cacheVersion || c.Root != root {
+			c.Files = nil
+		}
+	}
+	c.Version, c.Root = cacheVersion, root
+	return c
+}
+
+// lookup returns the entry for a file whose mtime and size are unchanged
+func (c *localCache) lookup(rel string, info os.FileInfo) *localCacheEntry {
+	e, ok := c.Files[rel]
+	if !ok || e.ModTime != info.ModTime().UnixNano() || e.Size != info.Size() {
+		return nil
+	}
+	c.next[rel] = e
+	return e
+}
+
+// lookupContent returns the entry for a file whose content is unchanged (e.g. touched or checked out again)
+func (c *localCache) lookupContent(rel string, info os.FileInfo, hash string) *localCacheEntry {
+	e, ok := c.Files[rel]
+	if !ok || e.Hash != hash {
+		return nil
+	}
+	e.ModTime, e.Size = info.ModTime().UnixNano(), info.Size()
+	c.next[rel] = e
+	c.dirty = true
+	return e
+}
+
+func (c *localCache) store(rel string, e *localCacheEntry) {
+	c.next[rel] = e
+	c.dirty = true
+}
+
+// save writes the cache if anything changed. Failures are ignored: the cache is only an optimization.
+func (c *localCache) save() {
+	if !c.dirty && len(c.next) == len(c.Files) {
+		return
+	}
+	c.Files = c.next
+	writeCacheFile(c.path, c)
+}
+
+// result converts an entry into what a fresh parse would have produced
+func (e *localCacheEntry) result() (*DocumentSchema, error) {
+	if e.Error != "" {
+		return nil, errors.New(e.Error)
+	}
+	doc := *e.Document // Callers may rewrite fields (e.g. CompositeProvider prefixes paths)
+	doc.Hash = e.Hash
+	return &doc, nil
+}
+
+// remoteCache is the last kex.json received from a remote reference
+type remoteCache struct {
+	URL          string          `json:"url"`
+	ETag         string          `json:"etag,omitempty"`
+	LastModified string          `json:"lastModified,omitempty"`
+	Index        json.RawMessage `json:"index"`
+}
+
+func remoteCachePath(cacheDir, url string) string {
+	return filepath.Join(cacheDir, "remote-"+cacheKey(url)+".json")
+}
+
+func readRemoteCache(path, url string) *remoteCache {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var c remoteCache
+	if json.Unmarshal(data, &c) != nil || c.URL != url || len(c.Index) == 0 {
+		return nil
+	}
+	return &c
+}
+
+func contentHash(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// cacheKey derives a short file name component from a root path or URL
+func cacheKey(s string) string {
+	return contentHash([]byte(s))[:16]
+}
+
+// writeCacheFile writes JSON atomically so a crash never leaves a truncated cache
+func writeCacheFile(path string, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	if err := EnsureDir(filepath.Dir(path)); err != nil {
+		return
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return
+	}
+	_, writeErr := tmp.Write(data)
+	closeErr := tmp.Close()
+	if writeErr != nil || closeErr != nil {
+		os.Remove(tmp.Name())
+		return
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+	}
+}
@@ -0,0 +1,126 @@
+package fs
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestLocalProvider_Cache(t *testing.T) {
+	root := t.TempDir()
+	cacheDir := t.TempDir()
+	stamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	write := func(name, content string) {
+		path := filepath.Join(root, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chtimes(path, stamp, stamp); err != nil {
+			t.Fatal(err)
+		}
+	}
+	load := func() (map[string]string, int) {
+		p := NewLocalProvider(root, &logger.NoOpLogger{})
+		p.CacheDir = cacheDir
+		schema, errs := p.Load()
+		titles := make(map[string]string)
+		for _, d := range schema.Documents {
+			titles[d.ID] = d.Title
+		}
+		return titles, len(errs)
+	}
+
+	write("a.md", "---\ntitle: Alpha\n---\nbody\n")
+	write("b.md", "---\ntitle: Beta\n---\nbody\n")
+	write("broken.md", "no frontmatter")
+
+	titles, errCount := load()
+	if titles["a"] != "Alpha" || titles["b"] != "Beta" || errCount != 1 {
+		t.Fatalf("unexpected first load: %v (%d errors)", titles, errCount)
+	}
+
+	t.Run("it should reuse entries while mtime and size are unchanged", func(t *testing.T) {
+		// Same size and mtime: only a cache hit can still report the old title
+		write("a.md", "---\ntitle: Alpho\n---\nbody\n")
+		titles, errCount := load()
+		if titles["a"] != "Alpha" {
+			t.Errorf("expected cached title Alpha, got %q", titles["a"])
+		}
+		if errCount != 1 {
+			t.Errorf("expected the cached parse error to be reported again, got %d errors", errCount)
+		}
+	})
+
+	t.Run("it should re-parse files whose size changed", func(t *testing.T) {
+		write("a.md", "---\ntitle: Alpha Two\n---\nbody\n")
+		if titles, _ := load(); titles["a"] != "Alpha Two" {
+			t.Errorf("expected updated title, got %q", titles["a"])
+		}
+	})
+
+	t.Run("it should drop deleted files", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(root, "b.md")); err != nil {
+			t.Fatal(err)
+		}
+		titles, _ := load()
+		var ids []string
+		for id := range titles {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+		if len(ids) != 1 || ids[0] != "a" {
+			t.Errorf("expected only a, got %v", ids)
+		}
+	})
+}
+
+func TestRemoteProvider_Cache(t *testing.T) {
+	const etag = `"v1"`
+	requests, notModified := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`{"documents":[{"id":"remote-doc","title":"Remote","path":"remote-doc.md"}]}`))
+	}))
+
+	cacheDir := t.TempDir()
+	load := func() *IndexSchema {
+		p := NewRemoteProvider(srv.URL, "", &logger.NoOpLogger{})
+		p.CacheDir = cacheDir
+		schema, errs := p.Load()
+		if len(errs) > 0 {
+			t.Fatalf("unexpected errors: %v", errs)
+		}
+		return schema
+	}
+
+	if schema := load(); len(schema.Documents) != 1 {
+		t.Fatalf("expected 1 document, got %d", len(schema.Documents))
+	}
+
+	t.Run("it should revalidate with the cached ETag", func(t *testing.T) {
+		schema := load()
+		if notModified != 1 || len(schema.Documents) != 1 || schema.Documents[0].ID != "remote-doc" {
+			t.Errorf("expected cached index after 304, got %d documents (304s: %d)", len(schema.Documents), notModified)
+		}
+	})
+
+	t.Run("it should fall back to the cached index when offline", func(t *testing.T) {
+		srv.Close()
+		if schema := load(); len(schema.Documents) != 1 {
+			t.Errorf("expected cached index, got %d documents", len(schema.Documents))
+		}
+	})
+}
@@ -25,14 +25,24 @@ func NewProviderFactory(cfg config.Config, l logger.Logger) *ProviderFactory {
 
 // CreateProvider creates a DocumentProvider for the given path or URL.
 // It handles local paths and remote URLs, including token resolution for remote sources.
+// Providers cache what they load under the project's .kex/cache (see CacheDir).
+// References with a configured namespace have their document IDs prefixed.
 func (f *ProviderFactory) CreateProvider(pathOrURL string, isReference bool, cwd string) (DocumentProvider, string, error) {
+	var provider DocumentProvider
+	var resolved string
+	var err error
 	if isURL(pathOrURL) {
-		return f.createRemoteProvider(pathOrURL)
+		provider, resolved, err = f.createRemoteProvider(pathOrURL, cwd)
+	} else {
+		provider, resolved, err = f.createLocalProvider(pathOrURL, isReference, cwd)
+	}
+	if err != nil || !isReference {
+		return provider, resolved, err
 	}
-	return f.createLocalProvider(pathOrURL, isReference, cwd)
+	return NewNamespacedProvider(provider, f.cfg.Namespaces[pathOrURL]), resolved, nil
 }
 
-func (f *ProviderFactory) createRemoteProvider(url string) (DocumentProvider, string, error) {
+func (f *ProviderFactory) createRemoteProvider(url, cwd string) (DocumentProvider, string, error) {
 	token := os.Getenv("KEX_REMOTE_TOKEN")
 	if token == "" && f.cfg.RemoteToken != "" {
 		token = f.cfg.RemoteToken
@@ -40,7 +50,9 @@ func (f *ProviderFactory) createRemoteProvider(url string) (DocumentProvider, st
 
 	// Logging is handled by the caller or provider itself usually, but check.go/start.go did some stdout logging.
 	// We'll leave UI logging to the CLI layer, this factory just returns the provider.
-	return NewRemoteProvider(url, token, f.logger), url, nil
+	provider := NewRemoteProvider(url, token, f.logger)
+	provider.CacheDir = CacheDir(cwd)
+	return provider, url, nil
 }
 
 func (f *ProviderFactory) createLocalProvider(path string, isReference bool, cwd string) (DocumentProvider, string, error) {
@@ -58,7 +70,9 @@ func (f *ProviderFactory) createLocalProvider(path string, isReference bool, cwd
 		return nil, "", fmt.Errorf("source '%s' not found", path)
 	}
 
-	return NewLocalProvider(fullPath, f.logger), fullPath, nil
+	provider := NewLocalProvider(fullPath, f.logger)
+	provider.CacheDir = CacheDir(cwd)
+	return provider, fullPath, nil
 }
 
 func isURL(s string) bool {
@@ -0,0 +1,152 @@
+package fs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// bodyFetchConcurrency bounds the bodies fetched in parallel
+const bodyFetchConcurrency = 8
+
+// bodyRetryInterval is how long a body that failed to fetch is skipped before it is fetched again
+// (a variable so tests can shorten it)
+var bodyRetryInterval = time.Minute
+
+// pendingBody is a document whose body is not yet in the full-text index
+type pendingBody struct {
+	doc  *domain.Document
+	body string // Body already loaded (e.g. by GetByID); "" = fetch it
+}
+
+// indexLocalBodies eagerly indexes bodies of documents served from local providers.
+// Remote bodies are indexed on demand (see ensureBodiesIndexed).
+func (i *Indexer) indexLocalBodies() {
+	local, ok := i.Provider.(LocalContentProvider)
+	if !ok {
+		return
+	}
+
+	var pending []pendingBody
+	for _, doc := range i.sortedDocuments() {
+		if local.IsLocal(doc.Path) {
+			pending = append(pending, pendingBody{doc: doc, body: i.bodies[doc.ID]})
+		}
+	}
+	i.indexBodies(context.Background(), pending)
+}
+
+// ensureBodiesIndexed fetches and indexes any body not yet in the full-text index.
+// This is where remote documents are indexed, on the first full-text search.
+// Once every body is indexed, it only takes the read lock.
+// The context cancels the fetches (the search then runs on the bodies indexed so far).
+func (i *Indexer) ensureBodiesIndexed(ctx context.Context) {
+	if len(i.pendingBodies()) == 0 {
+		return
+	}
+
+	// One search fetches at a time; the others wait for its bodies instead of fetching them again
+	i.fetchMu.Lock()
+	defer i.fetchMu.Unlock()
+	if ctx.Err() != nil {
+		return
+	}
+	i.indexBodies(ctx, i.pendingBodies())
+}
+
+// pendingBodies lists the documents to fetch and index, skipping recent failures
+func (i *Indexer) pendingBodies() []pendingBody {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.bodiesComplete {
+		return nil
+	}
+
+	var pending []pendingBody
+	now := time.Now()
+	for _, doc := range i.sortedDocuments() {
+		if _, done := i.bodyIndexed[doc.ID]; done {
+			continue
+		}
+		if failedAt, failed := i.bodyFailed[doc.ID]; failed && now.Sub(failedAt) < bodyRetryInterval {
+			continue
+		}
+		pending = append(pending, pendingBody{doc: doc, body: i.bodies[doc.ID]})
+	}
+	return pending
+}
+
+// indexBodies fetches the missing bodies in parallel without holding the lock,
+// then adds them to the full-text index
+func (i *Indexer) indexBodies(ctx context.Context, pending []pendingBody) {
+	errs := make([]error, len(pending))
+	sem := make(chan struct{}, bodyFetchConcurrency)
+	var wg sync.WaitGroup
+	for n := range pending {
+		if pending[n].body != "" {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			pending[n].body, errs[n] = i.fetchBody(ctx, pending[n].doc)
+		}()
+	}
+	wg.Wait()
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	for n, p := range pending {
+		if errs[n] != nil && ctx.Err() != nil {
+			// Cancelled by the caller: not the source's fault, so the next search fetches it again
+			continue
+		}
+		if errs[n] != nil {
+			// Remember the failure so a broken remote is not re-fetched on every query
+			i.bodyFailed[p.doc.ID] = time.Now()
+			i.Logger.Error("Failed to fetch content for %s: %v", p.doc.ID, errs[n])
+			continue
+		}
+		i.storeBody(p.doc, p.body)
+	}
+}
+
+// storeBody keeps a fetched body and adds it to the full-text index when enabled.
+// The write lock must be held.
+func (i *Indexer) storeBody(doc *domain.Document, content string) {
+	if _, ok := i.bodies[doc.ID]; !ok {
+		i.bodies[doc.ID] = content
+	}
+	delete(i.bodyFailed, doc.ID)
+	if i.FullText {
+		i.indexBody(doc)
+	}
+}
+
+// indexBody adds the document body to the full-text index (once per document)
+func (i *Indexer) indexBody(doc *domain.Document) {
+	if _, done := i.bodyIndexed[doc.ID]; done {
+		return
+	}
+	i.bodyIndexed[doc.ID] = struct{}{}
+	i.bodiesComplete = len(i.bodyIndexed) == len(i.Documents)
+
+	// Headings, paragraphs and code fences are all indexed; markdown syntax
+	// is dropped by the tokenizer.
+	tokens := i.Tokenizer.Tokenize(i.bodies[doc.ID])
+	terms := tokenTerms(tokens)
+	seen := make(map[string]struct{})
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		i.FullTextIndex[term] = append(i.FullTextIndex[term], doc)
+		i.vocabulary.add(term)
+	}
+	i.ranking.addTokens(doc, FieldBody, tokens)
+}
@@ -0,0 +1,223 @@
+package fs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mew-ton/kex/internal/domain"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_FullText(t *testing.T) {
+	doc := "---\ntitle: Concurrency\nkeywords: [goroutine]\n---\n## Guidance\nPrefer `errgroup.Group` over raw WaitGroups.\n"
+
+	t.Run("it should find local documents by body terms when enabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "concurrency.md"), []byte(doc), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		l := &logger.NoOpLogger{}
+		idx := New(NewCompositeProvider([]DocumentProvider{NewLocalProvider(tmpDir, l)}), l)
+		idx.FullText = true
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := idx.FullTextIndex["errgroup"]; !ok {
+			t.Error("expected local body to be indexed at load time")
+		}
+
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}})
+		if len(got) != 1 || got[0].ID != "concurrency" {
+			t.Errorf("expected [concurrency], got %v", got)
+		}
+	})
+
+	t.Run("it should not search bodies when disabled", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(tmpDir, "concurrency.md"), []byte(doc), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		l := &logger.NoOpLogger{}
+		idx := New(NewLocalProvider(tmpDir, l), l)
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}}); len(got) != 0 {
+			t.Errorf("expected no results, got %d", len(got))
+		}
+	})
+
+	t.Run("it should index remote bodies on the first search", func(t *testing.T) {
+		remote := &MockProvider{
+			Documents: []*DocumentSchema{{ID: "remote", Title: "Remote", Path: "remote.md"}},
+			Content:   map[string]string{"remote.md": "Always use errgroup."},
+		}
+
+		l := &logger.NoOpLogger{}
+		idx := New(NewCompositeProvider([]DocumentProvider{remote}), l)
+		idx.FullText = true
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, ok := idx.FullTextIndex["errgroup"]; ok {
+			t.Error("expected remote body not to be fetched at load time")
+		}
+
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}})
+		if len(got) != 1 || got[0].ID != "remote" {
+			t.Errorf("expected [remote], got %v", got)
+		}
+	})
+}
+
+// countingProvider serves remote content, counting fetches; failing paths return an error
+type countingProvider struct {
+	MockProvider
+	fetches atomic.Int32
+	mu      sync.Mutex
+	failing map[string]bool
+}
+
+func (p *countingProvider) FetchContent(ctx context.Context, path string) (string, error) {
+	p.fetches.Add(1)
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.failing[path] {
+		return "", fmt.Errorf("unavailable")
+	}
+	return p.MockProvider.FetchContent(ctx, path)
+}
+
+func TestIndexer_FullText_RemoteBodies(t *testing.T) {
+	newIndexer := func(t *testing.T, remote *countingProvider) *Indexer {
+		l := &logger.NoOpLogger{}
+		idx := New(NewCompositeProvider([]DocumentProvider{remote}), l)
+		idx.FullText = true
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		return idx
+	}
+	newRemote := func() *countingProvider {
+		return &countingProvider{
+			MockProvider: MockProvider{
+				Documents: []*DocumentSchema{
+					{ID: "a", Title: "A", Path: "a.md"},
+					{ID: "b", Title: "B", Path: "b.md"},
+				},
+				Content: map[string]string{"a.md": "Always use errgroup.", "b.md": "Prefer errgroup."},
+			},
+			failing: map[string]bool{},
+		}
+	}
+
+	t.Run("it should fetch each body once under concurrent searches", func(t *testing.T) {
+		remote := newRemote()
+		idx := newIndexer(t, remote)
+
+		var wg sync.WaitGroup
+		for n := 0; n < 8; n++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}}); len(got) != 2 {
+					t.Errorf("expected 2 results, got %d", len(got))
+				}
+			}()
+		}
+		wg.Wait()
+
+		if got := remote.fetches.Load(); got != 2 {
+			t.Errorf("expected 2 fetches, got %d", got)
+		}
+	})
+
+	t.Run("it should retry a body that failed to fetch", func(t *testing.T) {
+		defer func(interval time.Duration) { bodyRetryInterval = interval }(bodyRetryInterval)
+		bodyRetryInterval = time.Hour
+
+		remote := newRemote()
+		remote.failing["b.md"] = true
+		idx := newIndexer(t, remote)
+
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}}); len(got) != 1 {
+			t.Fatalf("expected only the fetched body to match, got %d", len(got))
+		}
+		remote.mu.Lock()
+		remote.failing["b.md"] = false
+		remote.mu.Unlock()
+
+		idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}})
+		if got := remote.fetches.Load(); got != 2 {
+			t.Fatalf("expected no retry within the interval, got %d fetches", got)
+		}
+
+		bodyRetryInterval = 0
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}}); len(got) != 2 {
+			t.Errorf("expected the retried body to match, got %d", len(got))
+		}
+	})
+
+	t.Run("it should not mutate shared documents while fetching bodies", func(t *testing.T) {
+		remote := newRemote()
+		idx := newIndexer(t, remote)
+
+		// Run with -race: readers of the shared documents must not see the fetched bodies being written
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}})
+		}()
+		go func() {
+			defer wg.Done()
+			if doc, ok := idx.GetByID("a"); !ok || doc.Body != "Always use errgroup." {
+				t.Errorf("expected the fetched body, got %+v", doc)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for _, doc := range idx.GetAll() {
+				_ = doc.EstimatedTokens()
+			}
+		}()
+		wg.Wait()
+
+		for _, doc := range idx.GetAll() {
+			if doc.Body != "" {
+				t.Errorf("expected the shared document %s to keep no body, got %q", doc.ID, doc.Body)
+			}
+		}
+	})
+
+	t.Run("it should not fetch or mark bodies as failed for a cancelled search", func(t *testing.T) {
+		remote := newRemote()
+		idx := newIndexer(t, remote)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		idx.SearchContext(ctx, domain.SearchQuery{Keywords: []string{"errgroup"}})
+		if got := remote.fetches.Load(); got != 0 {
+			t.Fatalf("expected no fetches, got %d", got)
+		}
+
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"errgroup"}}); len(got) != 2 {
+			t.Errorf("expected the next search to fetch both bodies, got %d", len(got))
+		}
+	})
+}
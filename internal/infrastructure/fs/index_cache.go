@@ -0,0 +1,251 @@
+package fs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/embedding"
+)
+
+// indexCache persists the derived indexes of a load: the ranking postings, the
+// keyword and full-text indexes, the vocabulary (with its BK-tree) and the local
+// bodies they were built from. It is reused only when its key matches, i.e. the
+// same documents (local content included) were loaded with the same settings.
+type indexCache struct {
+	Version    int                                 `json:"version"`
+	Key        string                              `json:"key"`
+	Postings   map[string]map[string]*postingCache `json:"postings"` // Term -> DocID -> Posting
+	Lengths    map[string]map[Field]int            `json:"lengths"`  // DocID -> Field -> Term count
+	Keywords   map[string][]string                 `json:"keywords"` // Term -> DocIDs
+	FullText   map[string][]string                 `json:"fullText"` // Term -> DocIDs
+	Bodies     map[string]string                   `json:"bodies"`   // DocID -> Indexed body
+	Vocabulary *bkNodeCache                        `json:"vocabulary,omitempty"`
+	Vectors    map[string][]float32                `json:"vectors,omitempty"` // Embedder vectors (not for corpus embedders)
+}
+
+type postingCache struct {
+	Freqs map[Field]int      `json:"f"`
+	Spans map[Field][][2]int `json:"s,omitempty"`
+}
+
+type bkNodeCache struct {
+	Term     string               `json:"t"`
+	Children map[int]*bkNodeCache `json:"c,omitempty"`
+}
+
+// indexCacheKeyInput is everything the derived indexes depend on
+type indexCacheKeyInput struct {
+	Version   int               `json:"version"`
+	Tokenizer string            `json:"tokenizer"`
+	FullText  bool              `json:"fullText"`
+	Embedder  string            `json:"embedder,omitempty"`
+	Documents []*DocumentSchema `json:"documents"`
+	Hashes    []string          `json:"hashes"` // Content hashes of local files, in document order
+}
+
+func (i *Indexer) indexCachePath() string {
+	return filepath.Join(i.CacheDir, "index.json")
+}
+
+// indexCacheKey fingerprints the loaded documents and the settings that shape the index
+// ("" when caching is disabled)
+func (i *Indexer) indexCacheKey(schema *IndexSchema) string {
+	if i.CacheDir == "" || i.extendsRemote(schema) {
+		return ""
+	}
+	tokenizer, err := json.Marshal(i.Tokenizer)
+	if err != nil {
+		return ""
+	}
+	input := indexCacheKeyInput{
+		Version:   cacheVersion,
+		Tokenizer: fmt.Sprintf("%T%s", i.Tokenizer, tokenizer),
+		FullText:  i.FullText,
+		Documents: schema.Documents,
+	}
+	if i.Embedder != nil {
+		input.Embedder = i.Embedder.Name()
+	}
+	for _, sd := range schema.Documents {
+		input.Hashes = append(input.Hashes, sd.Hash)
+	}
+	data, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	return contentHash(data)
+}
+
+// extendsRemote reports whether a document extends one served by a remote provider.
+// Its indexed body then includes the remote body, which the key cannot fingerprint without fetching it.
+func (i *Indexer) extendsRemote(schema *IndexSchema) bool {
+	local, _ := i.Provider.(LocalContentProvider)
+	paths := make(map[string]string, len(schema.Documents)) // ID -> Path of the document that wins it
+	for _, sd := range schema.Documents {
+		if _, dup := paths[sd.ID]; !dup {
+			paths[sd.ID] = sd.Path
+		}
+	}
+	for _, sd := range schema.Documents {
+		if sd.Overrides == "" || domain.OverrideMode(sd.OverrideMode) != domain.OverrideExtend {
+			continue
+		}
+		if base, ok := paths[sd.Overrides]; ok && (local == nil || !local.IsLocal(base)) {
+			return true
+		}
+	}
+	return false
+}
+
+// readIndexCache returns the cached index for the key; a missing, outdated or mismatched cache returns nil
+func (i *Indexer) readIndexCache(key string) *indexCache {
+	if key == "" {
+		return nil
+	}
+	data, err := os.ReadFile(i.indexCachePath())
+	if err != nil {
+		return nil
+	}
+	var c indexCache
+	if json.Unmarshal(data, &c) != nil || c.Version != cacheVersion || c.Key != key {
+		return nil
+	}
+	return &c
+}
+
+// restoreIndex rebuilds the derived indexes from the cache instead of tokenizing the documents
+func (i *Indexer) restoreIndex(c *indexCache) {
+	for term, postings := range c.Postings {
+		docs := make(map[string]*posting, len(postings))
+		for id, pc := range postings {
+			p := &posting{doc: i.Documents[id], freqs: pc.Freqs}
+			if len(pc.Spans) > 0 {
+				p.spans = make(map[Field][]span, len(pc.Spans))
+				for field, spans := range pc.Spans {
+					for _, s := range spans {
+						p.spans[field] = append(p.spans[field], span{start: s[0], end: s[1]})
+					}
+				}
+			}
+			docs[id] = p
+		}
+		i.ranking.postings[term] = docs
+	}
+	for id, lengths := range c.Lengths {
+		i.ranking.fieldLengths[id] = lengths
+		for field, n := range lengths {
+			i.ranking.totalLengths[field] += n
+		}
+	}
+
+	i.KeywordIndex = i.documentLists(c.Keywords)
+	i.FullTextIndex = i.documentLists(c.FullText)
+	for id, body := range c.Bodies {
+		i.bodies[id] = body
+		i.bodyIndexed[id] = struct{}{}
+	}
+	i.bodiesComplete = len(i.bodyIndexed) == len(i.Documents)
+
+	// The trie is cheap to rebuild; the BK-tree is restored as it was built
+	i.vocabulary.bk.root = c.Vocabulary.restore(i.vocabulary)
+
+	if c.Vectors != nil {
+		i.VectorIndex = c.Vectors
+	}
+}
+
+// documentLists resolves cached term -> ID lists to documents
+func (i *Indexer) documentLists(ids map[string][]string) map[string][]*domain.Document {
+	lists := make(map[string][]*domain.Document, len(ids))
+	for term, list := range ids {
+		for _, id := range list {
+			lists[term] = append(lists[term], i.Documents[id])
+		}
+	}
+	return lists
+}
+
+// restore rebuilds a BK-tree node, adding its terms to the vocabulary
+func (n *bkNodeCache) restore(v *vocabulary) *bkNode {
+	if n == nil {
+		return nil
+	}
+	v.terms[n.Term] = struct{}{}
+	v.trie.insert(n.Term)
+	node := &bkNode{term: n.Term, children: make(map[int]*bkNode, len(n.Children))}
+	for dist, child := range n.Children {
+		node.children[dist] = child.restore(v)
+	}
+	return node
+}
+
+func newBKNodeCache(n *bkNode) *bkNodeCache {
+	if n == nil {
+		return nil
+	}
+	c := &bkNodeCache{Term: n.term}
+	if len(n.children) > 0 {
+		c.Children = make(map[int]*bkNodeCache, len(n.children))
+		for dist, child := range n.children {
+			c.Children[dist] = newBKNodeCache(child)
+		}
+	}
+	return c
+}
+
+// writeIndexCache saves the derived indexes just built. Failures are ignored: the cache is only an optimization.
+func (i *Indexer) writeIndexCache(key string) {
+	if key == "" {
+		return
+	}
+	c := &indexCache{
+		Version:    cacheVersion,
+		Key:        key,
+		Postings:   make(map[string]map[string]*postingCache, len(i.ranking.postings)),
+		Lengths:    i.ranking.fieldLengths,
+		Keywords:   documentIDLists(i.KeywordIndex),
+		FullText:   documentIDLists(i.FullTextIndex),
+		Bodies:     make(map[string]string, len(i.bodyIndexed)),
+		Vocabulary: newBKNodeCache(i.vocabulary.bk.root),
+	}
+	for term, postings := range i.ranking.postings {
+		docs := make(map[string]*postingCache, len(postings))
+		for id, p := range postings {
+			pc := &postingCache{Freqs: p.freqs}
+			if len(p.spans) > 0 {
+				pc.Spans = make(map[Field][][2]int, len(p.spans))
+				for field, spans := range p.spans {
+					for _, s := range spans {
+						pc.Spans[field] = append(pc.Spans[field], [2]int{s.start, s.end})
+					}
+				}
+			}
+			docs[id] = pc
+		}
+		c.Postings[term] = docs
+	}
+	for id := range i.bodyIndexed {
+		c.Bodies[id] = i.bodies[id]
+	}
+
+	// Corpus embedders are fitted on every load anyway, and an incomplete set would never be retried
+	if _, corpus := i.Embedder.(embedding.CorpusEmbedder); i.Embedder != nil && !corpus && len(i.VectorIndex) == len(i.Documents) {
+		c.Vectors = i.VectorIndex
+	}
+
+	writeCacheFile(i.indexCachePath(), c)
+}
+
+// documentIDLists keeps the IDs of a term -> documents index, in index order
+func documentIDLists(index map[string][]*domain.Document) map[string][]string {
+	ids := make(map[string][]string, len(index))
+	for term, docs := range index {
+		for _, doc := range docs {
+			ids[term] = append(ids[term], doc.ID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,131 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+// countingTokenizer counts the texts tokenized while indexing
+type countingTokenizer struct {
+	*StandardTokenizer
+	calls int
+}
+
+func (c *countingTokenizer) Tokenize(text string) []Token {
+	c.calls++
+	return c.StandardTokenizer.Tokenize(text)
+}
+
+func TestIndexer_IndexCache(t *testing.T) {
+	root := t.TempDir()
+	cacheDir := t.TempDir()
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	load := func() (*Indexer, *countingTokenizer) {
+		l := &logger.NoOpLogger{}
+		tokenizer := &countingTokenizer{StandardTokenizer: NewTokenizer(LanguageEnglish, nil)}
+		idx := New(NewLocalProvider(root, l), l)
+		idx.FullText = true
+		idx.CacheDir = cacheDir
+		idx.Tokenizer = tokenizer
+		idx.Matching = MatchOptions{Fuzzy: true}
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		return idx, tokenizer
+	}
+	// summary keeps what a search result shows, without the document pointers
+	summary := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID, r.Snippet, r.Section)
+		}
+		return out
+	}
+
+	write("retries.md", "---\ntitle: Retry Policy\nkeywords: [network]\n---\n# Backoff\n\nRetry with exponential backoff.\n")
+	write("logging.md", "---\ntitle: Structured Logging\n---\nLog as JSON.\n")
+
+	first, tokenizer := load()
+	if tokenizer.calls == 0 {
+		t.Fatal("expected the first load to tokenize the documents")
+	}
+	query := domain.SearchQuery{Keywords: []string{"exponentail"}}
+	want := summary(first.Search(query))
+	if len(want) == 0 {
+		t.Fatal("expected a fuzzy body match")
+	}
+
+	t.Run("it should restore the index without tokenizing the documents again", func(t *testing.T) {
+		idx, tokenizer := load()
+		if tokenizer.calls != 0 {
+			t.Errorf("expected no tokenizing on load, got %d calls", tokenizer.calls)
+		}
+		if got := summary(idx.Search(query)); !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v from the restored index, got %v", want, got)
+		}
+		if doc, ok := idx.GetByID("retries"); !ok || doc.Body == "" {
+			t.Error("expected the indexed body to be restored")
+		}
+	})
+
+	t.Run("it should rebuild the index when a document changes", func(t *testing.T) {
+		write("logging.md", "---\ntitle: Structured Logging\n---\nLog as JSON with a correlation ID.\n")
+		idx, tokenizer := load()
+		if tokenizer.calls == 0 {
+			t.Error("expected the changed documents to be tokenized")
+		}
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"correlation"}}); len(got) != 1 || got[0].ID != "logging" {
+			t.Errorf("expected the new body to be searchable, got %v", got)
+		}
+	})
+
+	t.Run("it should rebuild the index when the settings change", func(t *testing.T) {
+		l := &logger.NoOpLogger{}
+		tokenizer := &countingTokenizer{StandardTokenizer: NewTokenizer(LanguageNone, nil)}
+		idx := New(NewLocalProvider(root, l), l)
+		idx.FullText = true
+		idx.CacheDir = cacheDir
+		idx.Tokenizer = tokenizer
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if tokenizer.calls == 0 {
+			t.Error("expected a different tokenizer to invalidate the cache")
+		}
+	})
+	t.Run("it should not cache an index that includes remote bodies", func(t *testing.T) {
+		l := &logger.NoOpLogger{}
+		write("errors.md", "---\ntitle: Project Errors\noverrides: org.errors\noverrideMode: extend\n---\nUse our error codes.\n")
+		defer os.Remove(filepath.Join(root, "errors.md"))
+		cacheDir := t.TempDir()
+		load := func(upstream string) *Indexer {
+			remote := &MockProvider{
+				Documents: []*DocumentSchema{{ID: "org.errors", Title: "Errors", Path: "errors.md"}},
+				Content:   map[string]string{"errors.md": upstream},
+			}
+			idx := New(NewCompositeProvider([]DocumentProvider{NewLocalProvider(root, l), remote}), l)
+			idx.FullText = true
+			idx.CacheDir = cacheDir
+			if err := idx.Load(); err != nil {
+				t.Fatal(err)
+			}
+			return idx
+		}
+
+		load("Wrap errors.")
+		idx := load("Wrap errors with sentinel values.")
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"sentinel"}}); len(got) != 1 || got[0].ID != "errors" {
+			t.Errorf("expected the changed upstream body in the extended document, got %v", got)
+		}
+	})
+}
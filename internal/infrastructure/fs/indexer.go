@@ -1,10 +1,16 @@
 package fs
 
 import (
+	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
 
 	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/embedding"
 	"github.com/mew-ton/kex/internal/infrastructure/logger"
 )
 
@@ -13,27 +19,66 @@ type Indexer struct {
 	Provider DocumentProvider
 	Logger   logger.Logger
 
-	IncludeDrafts bool                          // If true, draft documents are indexed
-	Documents     map[string]*domain.Document   // ID -> Document
-	KeywordIndex  map[string][]*domain.Document // Keyword -> Documents
-	ScopeIndex    map[string][]*domain.Document // Scope -> Documents (Exact match)
-	Errors        []error                       // Validation errors found during load
-	Schema        *IndexSchema                  // Unified Schema
+	IncludeDrafts   bool                          // If true, draft documents are indexed
+	FullText        bool                          // If true, document bodies are indexed for search
+	Documents       map[string]*domain.Document   // ID -> Document
+	KeywordIndex    map[string][]*domain.Document // Keyword -> Documents
+	ScopeIndex      map[string][]*domain.Document // Scope -> Documents (Exact match)
+	FullTextIndex   map[string][]*domain.Document // Body Term -> Documents (FullText only)
+	Errors          []error                       // Validation errors found during load
+	Warnings        []error                       // Non-fatal problems found during load (e.g. shadowed duplicate IDs)
+	StrictIDs       bool                          // If true, duplicate IDs are load errors rather than warnings (kex check)
+	Schema          *IndexSchema                  // Unified Schema
+	FieldWeights    map[Field]float64             // Per-field ranking boosts (nil = DefaultFieldWeights)
+	SeverityWeights map[domain.Severity]float64   // Score multipliers per severity (nil = DefaultSeverityWeights)
+	Matching        MatchOptions                  // Default term expansion (overridable per query)
+	Tokenizer       Tokenizer                     // Splits text into terms for indexing and queries
+	Synonyms        Synonyms                      // Aliases from configuration (merged with source synonyms on Load)
+	Embedder        embedding.Embedder            // Enables semantic search (nil = keyword search only)
+	Semantic        SemanticOptions               // Blending of vector similarity into scores
+	VectorIndex     map[string][]float32          // ID -> Embedding (Embedder only)
+	CacheDir        string                        // Where derived indexes are cached between runs ("" = no cache)
+	ScopeMode       string                        // ScopeModeStrict (default) or ScopeModeInherit
+	Waivers         map[string]string             // ID -> Reason the guideline is waived in the project
+
+	mu      sync.RWMutex // Guards the bodies and their full-text indexing against concurrent searches
+	fetchMu sync.Mutex   // Lets one search at a time fetch missing bodies
+
+	ranking        *bm25Index
+	vocabulary     *vocabulary                 // All indexed terms (for prefix and fuzzy matching)
+	synonyms       *synonymTable               // Equivalent terms for query and scope expansion
+	scopes         *domain.ScopeNode           // Scope hierarchy of all documents
+	bodies         map[string]string           // ID -> Body, once loaded (shared documents are not mutated after Load)
+	bodyIndexed    map[string]struct{}         // IDs whose body is in the full-text index
+	bodiesComplete bool                        // Every body is in the full-text index
+	bodyFailed     map[string]time.Time        // ID -> When its body last failed to fetch
+	extends        map[string]*domain.Document // Local ID -> Referenced document it extends
 }
 
 // New creates a new Indexer
 func New(provider DocumentProvider, logger logger.Logger) *Indexer {
 	return &Indexer{
-		Provider:     provider,
-		Logger:       logger,
-		Documents:    make(map[string]*domain.Document),
-		KeywordIndex: make(map[string][]*domain.Document),
-		ScopeIndex:   make(map[string][]*domain.Document),
-		Errors:       []error{},
+		Provider:      provider,
+		Logger:        logger,
+		Documents:     make(map[string]*domain.Document),
+		KeywordIndex:  make(map[string][]*domain.Document),
+		ScopeIndex:    make(map[string][]*domain.Document),
+		FullTextIndex: make(map[string][]*domain.Document),
+		Errors:        []error{},
+		Tokenizer:     NewTokenizer(LanguageEnglish, nil),
+		ranking:       newBM25Index(nil),
+		vocabulary:    newVocabulary(),
+		synonyms:      newSynonymTable(nil),
+		scopes:        domain.NewScopeTree(),
+		bodies:        make(map[string]string),
+		bodyIndexed:   make(map[string]struct{}),
+		bodyFailed:    make(map[string]time.Time),
+		extends:       make(map[string]*domain.Document),
 	}
 }
 
-// Load scans the root directory and populates the index
+// Load scans the root directory and populates the index.
+// It must complete before the Indexer is shared (see LiveRepository for reloading).
 // Dogfooding: Top-down decomposition
 func (i *Indexer) Load() error {
 	// 1. Load Schema from Provider
@@ -51,17 +96,36 @@ func (i *Indexer) Load() error {
 	}
 
 	i.Schema = schema
+	i.ranking = newBM25Index(i.FieldWeights)
+	i.synonyms = newSynonymTable(mergeSynonyms(i.Synonyms, schema.Synonyms))
+
+	// A cached index of the same documents and settings replaces tokenizing them again
+	key := i.indexCacheKey(schema)
+	cached := i.readIndexCache(key)
 
 	// 2. Convert Schema to Domain Documents
 	for _, sd := range schema.Documents {
 		doc := &domain.Document{
-			ID:          sd.ID,
-			Title:       sd.Title,
-			Description: sd.Description,
-			Keywords:    sd.Keywords,
-			Scopes:      sd.Scopes,
-			Status:      domain.DocumentStatus(sd.Status),
-			Path:        sd.Path,
+			ID:           sd.ID,
+			Kind:         domain.DocumentKind(sd.Kind),
+			Title:        sd.Title,
+			Description:  sd.Description,
+			Keywords:     sd.Keywords,
+			Scopes:       sd.Scopes,
+			AppliesTo:    sd.AppliesTo,
+			Related:      sd.Related,
+			Supersedes:   sd.Supersedes,
+			Requires:     sd.Requires,
+			ReplacedBy:   sd.ReplacedBy,
+			Overrides:    sd.Overrides,
+			Status:       domain.DocumentStatus(sd.Status),
+			Severity:     domain.Severity(sd.Severity),
+			OverrideMode: domain.OverrideMode(sd.OverrideMode),
+			Arguments:    sd.Arguments,
+			Path:         sd.Path,
+			Source:       sd.Source,
+			SourcePath:   sd.SourcePath,
+			Size:         sd.Size,
 		}
 
 		// Map empty status to Adopted if missing?
@@ -69,22 +133,71 @@ func (i *Indexer) Load() error {
 		if doc.Status == "" {
 			doc.Status = domain.StatusAdopted
 		}
+		// Without a CompositeProvider the path is not prefixed
+		if doc.SourcePath == "" {
+			doc.SourcePath = doc.Path
+		}
+
+		// The first source wins; a later document with the same ID is reported instead of overwriting it
+		if existing, dup := i.Documents[doc.ID]; dup {
+			err := duplicateIDError(existing, doc)
+			if i.StrictIDs {
+				i.Errors = append(i.Errors, err)
+			} else {
+				i.Warnings = append(i.Warnings, err)
+			}
+			continue
+		}
 
 		i.addDocument(doc)
+		if cached == nil {
+			i.indexText(doc)
+		}
 	}
 
+	// 2b. Apply Project Policies (local overrides and waivers)
+	i.applyOverrides()
+	i.applyWaivers()
+
+	if cached != nil {
+		i.restoreIndex(cached)
+	} else if i.FullText {
+		// 3. Index Bodies (local sources eagerly, remote ones on first search)
+		i.indexLocalBodies()
+	}
+
+	// 4. Embed Documents for semantic search (unless the vectors came from the cache)
+	if i.Embedder != nil && i.VectorIndex == nil {
+		i.indexVectors(schema)
+	}
+
+	if cached == nil {
+		i.writeIndexCache(key)
+	}
 	return nil
 }
 
-// Export generates the IndexSchema from current valid documents (Adopted Only)
+// Export generates the IndexSchema from current valid documents.
+// Drafts are left out; retired documents are kept so consumers can follow their replacements.
 func (i *Indexer) Export() (*IndexSchema, error) {
 	schema := &IndexSchema{
 		// GeneratedAt logic?
 		Documents: []*DocumentSchema{},
 	}
 
+	// Ship the source's alias table so remote consumers expand queries the same way
+	if i.Schema != nil {
+		schema.Synonyms = i.Schema.Synonyms
+	}
+
+	// Ship vectors unless they depend on this corpus (remote consumers would embed queries differently)
+	var embeddingModel string
+	if _, corpus := i.Embedder.(embedding.CorpusEmbedder); i.Embedder != nil && !corpus {
+		embeddingModel = i.Embedder.Name()
+	}
+
 	for _, doc := range i.Documents {
-		if doc.Status != domain.StatusAdopted {
+		if doc.Status == domain.StatusDraft {
 			continue
 		}
 
@@ -97,10 +210,17 @@ func (i *Indexer) Export() (*IndexSchema, error) {
 
 		schema.Documents = append(schema.Documents, &DocumentSchema{
 			ID:          doc.ID,
+			Kind:        string(doc.Kind),
 			Title:       doc.Title,
 			Description: doc.Description,
 			Keywords:    doc.Keywords,
 			Scopes:      doc.Scopes,
+			AppliesTo:   doc.AppliesTo,
+			Related:     doc.Related,
+			Supersedes:  doc.Supersedes,
+			Requires:    doc.Requires,
+			ReplacedBy:  doc.ReplacedBy,
+			Overrides:   doc.Overrides,
 			// Status is implicitly adopted in kex.json output?
 			// Or we can include it.
 			// Task said "Status field removed (implict adopted)".
@@ -110,9 +230,18 @@ func (i *Indexer) Export() (*IndexSchema, error) {
 			// Or omit it if omitempty?
 			// If we filter only adopted, we can probably omit it if it matches default?
 			// But explicitness is fine.
-			Status: string(doc.Status),
-			Path:   doc.Path,
+			Status:       string(doc.Status),
+			Severity:     string(doc.Severity),
+			OverrideMode: string(doc.OverrideMode),
+			Arguments:    doc.Arguments,
+			Path:         doc.Path,
+			Size:         doc.Size,
 		})
+		if vector, ok := i.VectorIndex[doc.ID]; ok && embeddingModel != "" {
+			last := schema.Documents[len(schema.Documents)-1]
+			last.EmbeddingModel = embeddingModel
+			last.Embedding = vector
+		}
 	}
 	return schema, nil
 }
@@ -136,83 +265,230 @@ func (i *Indexer) parseDocuments(paths []string) ([]*domain.Document, []error) {
 	return docs, errs
 }
 
-func (i *Indexer) addDocument(doc *domain.Document) {
-	// Add to ID map
-	i.Documents[doc.ID] = doc
+// duplicateIDError reports a document dropped because an earlier source defines the same ID
+func duplicateIDError(kept, dropped *domain.Document) error {
+	return fmt.Errorf("duplicate document ID %q: using %s, ignoring %s; set a namespace for one of the references",
+		kept.ID, documentLocation(kept), documentLocation(dropped))
+}
 
-	// Helper to add to index
-	addToIndex := func(term string) {
-		k := strings.ToLower(strings.TrimSpace(term))
-		if k == "" {
-			return
-		}
-		i.KeywordIndex[k] = append(i.KeywordIndex[k], doc)
+// documentLocation names the file of a document and the source it came from
+func documentLocation(doc *domain.Document) string {
+	if doc.Source == "" {
+		return doc.Path
 	}
+	return fmt.Sprintf("%s from %s", doc.SourcePath, doc.Source)
+}
 
-	// 1. Index explicit keywords
-	for _, keyword := range doc.Keywords {
-		addToIndex(keyword)
-	}
+func (i *Indexer) addDocument(doc *domain.Document) {
+	// Add to ID map
+	i.Documents[doc.ID] = doc
+	i.scopes.Add(doc)
 
-	// 2. Index Scopes (Directory names)
+	// Index Scopes (Directory names)
 	// ONLY index the last scope (most specific) to prevent parent scopes from matching child documents
 	// Ref: https://github.com/mew-ton/kex/issues/63
 	if len(doc.Scopes) > 0 {
 		lastScope := doc.Scopes[len(doc.Scopes)-1]
-		scopeKey := strings.ToLower(strings.TrimSpace(lastScope))
+		scopeKey := normalizeTerm(lastScope)
 		if scopeKey != "" {
 			i.ScopeIndex[scopeKey] = append(i.ScopeIndex[scopeKey], doc)
 		}
 	}
+}
+
+// indexText adds the document metadata to the keyword index and the ranking
+// (skipped when the index is restored from the cache)
+func (i *Indexer) indexText(doc *domain.Document) {
+	// Helper to add to index
+	addToIndex := func(terms []string) {
+		for _, k := range terms {
+			i.KeywordIndex[k] = append(i.KeywordIndex[k], doc)
+			i.vocabulary.add(k)
+		}
+	}
 
-	// 3. Index Title words
-	titleWords := strings.Fields(doc.Title)
-	for _, word := range titleWords {
-		addToIndex(word)
+	// 1. Index explicit keywords (curated, so stopwords are kept)
+	for _, keyword := range doc.Keywords {
+		terms := i.Tokenizer.Terms(keyword)
+		addToIndex(terms)
+		i.ranking.add(doc, FieldKeywords, terms)
 	}
 
-	// 4. Index Description words
-	descWords := strings.Fields(doc.Description)
-	for _, word := range descWords {
-		addToIndex(word)
+	// 2. Scope names contribute to ranking (e.g. "go" ranks Go guidelines higher)
+	var scopeTerms []string
+	for _, scope := range doc.Scopes {
+		scopeTerms = append(scopeTerms, i.Tokenizer.Terms(scope)...)
 	}
+	i.ranking.add(doc, FieldScope, scopeTerms)
+
+	// 3. Index Title words (with offsets, for snippets)
+	titleTokens := i.Tokenizer.Tokenize(doc.Title)
+	addToIndex(tokenTerms(titleTokens))
+	i.ranking.addTokens(doc, FieldTitle, titleTokens)
+
+	// 4. Index Description words
+	descriptionTokens := i.Tokenizer.Tokenize(doc.Description)
+	addToIndex(tokenTerms(descriptionTokens))
+	i.ranking.addTokens(doc, FieldDescription, descriptionTokens)
 }
 
-// Search returns documents matching the keywords and scopes
-func (i *Indexer) Search(keywords []string, scopes []string, exactScopeMatch bool) []*domain.Document {
+// Search returns documents matching the query keywords and scopes, ordered by relevance
+func (i *Indexer) Search(query domain.SearchQuery) []domain.SearchResult {
+	return i.SearchContext(context.Background(), query)
+}
+
+// SearchContext is Search with a context that cancels fetching remote bodies and embedding the query
+func (i *Indexer) SearchContext(ctx context.Context, query domain.SearchQuery) []domain.SearchResult {
+	keywords, exactScopeMatch := query.Keywords, query.ExactScopeMatch
+
+	if i.FullText && (query.Expr != nil || !exactScopeMatch) {
+		i.ensureBodiesIndexed(ctx)
+	}
+
+	// Embed the query before locking so a slow embeddings endpoint does not hold up other callers
+	var queryVector []float32
+	if query.Expr != nil {
+		queryVector = i.embedQuery(ctx, textValues(positiveTerms(query.Expr)))
+	} else if !exactScopeMatch {
+		queryVector = i.embedQuery(ctx, keywords)
+	}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if query.Expr != nil {
+		return i.searchExpr(query, queryVector)
+	}
+
 	// 1. Identify Implicit Scopes from Keywords & Explicit Scopes
-	validScopes := i.inferScopes(keywords, scopes, exactScopeMatch)
+	validScopes := i.inferScopes(keywords, query.Scopes, exactScopeMatch)
+
+	// 2. Resolve Query Terms (tokenized, then expanded by prefix/fuzzy matching)
+	terms := i.resolveTerms(keywords, i.matchOptions(query))
 
-	// 2. Find Candidates (Standard OR Logic)
+	// 3. Find Candidates (Standard OR Logic)
 	// If exactScopeMatch is true, we ONLY match scopes, not keywords.
-	candidates := i.findCandidates(keywords, exactScopeMatch, validScopes)
+	candidates := i.findCandidates(terms, exactScopeMatch, validScopes)
+
+	// 3b. Add Semantically Similar Documents (Embedder only)
+	if queryVector != nil {
+		candidates = appendMissing(candidates, i.semanticCandidates(queryVector))
+	}
 
-	// 3. Filter Candidates by Scope Subset Rule
-	var results []*domain.Document
+	// 3c. Add Documents Targeting the Caller's File (appliesTo)
+	targets := i.fileTargets(query.FilePath)
+	if !exactScopeMatch {
+		var targeted []*domain.Document
+		for _, doc := range i.sortedDocuments() {
+			if _, ok := targets[doc.ID]; ok {
+				targeted = append(targeted, doc)
+			}
+		}
+		candidates = appendMissing(candidates, targeted)
+	}
+
+	// 4. Filter Candidates by Scope Subset Rule (or the nesting rule in inherit mode)
+	var results []domain.SearchResult
 	for _, doc := range candidates {
 		// Strict Rule: Document Scopes MUST be a subset of the Query Scopes (validScopes)
-		// Exception: If Document has NO scopes (Root doc), it is always included.
-		if isSubset(doc.Scopes, validScopes) {
-			results = append(results, doc)
+		// Exceptions: Documents with NO scopes (Root docs) and documents targeting the caller's file.
+		if doc.IsPrompt() || doc.IsHidden(false) {
+			continue
+		}
+		pattern, targeted := targets[doc.ID]
+		if !targeted && !i.scopeAllowed(doc.Scopes, validScopes) {
+			continue
+		}
+		result := i.newResult(doc, terms)
+		if targeted {
+			boostTarget(&result, pattern)
 		}
+		results = append(results, result)
 	}
 
+	// 5. Rank by Score (ties broken by ID for a stable order)
+	i.blendScores(results, queryVector)
+	i.applySeverity(results)
+	sortResults(results)
+
 	return results
 }
 
-// inferScopes combines explicit scopes and implicit scopes found in keywords
+// appendMissing appends the documents not yet in the list
+func appendMissing(list []*domain.Document, docs []*domain.Document) []*domain.Document {
+	found := make(map[string]struct{}, len(list))
+	for _, doc := range list {
+		found[doc.ID] = struct{}{}
+	}
+	for _, doc := range docs {
+		if _, ok := found[doc.ID]; !ok {
+			found[doc.ID] = struct{}{}
+			list = append(list, doc)
+		}
+	}
+	return list
+}
+
+// matchOptions applies per-query overrides on top of the indexer defaults
+func (i *Indexer) matchOptions(query domain.SearchQuery) MatchOptions {
+	opts := i.Matching
+	if query.Prefix != nil {
+		opts.Prefix = *query.Prefix
+	}
+	if query.Fuzzy != nil {
+		opts.Fuzzy = *query.Fuzzy
+	}
+	return opts
+}
+
+// resolveTerms tokenizes the keywords and expands each term against the vocabulary
+func (i *Indexer) resolveTerms(keywords []string, opts MatchOptions) []queryTerm {
+	var terms []queryTerm
+	for _, k := range i.synonyms.expandAll(keywords) {
+		for _, token := range i.Tokenizer.Terms(k) {
+			terms = append(terms, i.vocabulary.expand(token, opts)...)
+		}
+	}
+	return terms
+}
+
+// newResult scores the document and attaches a snippet of the field that matched
+func (i *Indexer) newResult(doc *domain.Document, terms []queryTerm) domain.SearchResult {
+	field, snippet, section := i.snippet(doc, terms)
+	return domain.SearchResult{
+		Document:     doc,
+		Score:        i.ranking.scoreTerms(doc.ID, terms),
+		MatchedField: string(field),
+		Snippet:      snippet,
+		Section:      section,
+	}
+}
+
+func sortResults(results []domain.SearchResult) {
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].ID < results[b].ID
+	})
+}
+
+// inferScopes combines explicit scopes and implicit scopes found in keywords.
+// Both are expanded with synonyms, so "golang" selects the "go" scope.
 func (i *Indexer) inferScopes(keywords []string, explicitScopes []string, exactScopeMatch bool) map[string]struct{} {
 	validScopes := make(map[string]struct{})
+	keywords = i.synonyms.expandAll(keywords)
+	explicitScopes = i.synonyms.expandAll(explicitScopes)
 
 	// Add Explicit Scopes
 	for _, s := range explicitScopes {
-		validScopes[strings.ToLower(s)] = struct{}{}
+		validScopes[normalizeTerm(s)] = struct{}{}
 	}
 
 	// Add Implicit Scopes (if not exactScopeMatch mode)
 	if !exactScopeMatch {
 		for _, k := range keywords {
-			lowerK := strings.ToLower(k)
+			lowerK := normalizeTerm(k)
 			if _, ok := i.ScopeIndex[lowerK]; ok {
 				validScopes[lowerK] = struct{}{}
 			}
@@ -220,15 +496,16 @@ func (i *Indexer) inferScopes(keywords []string, explicitScopes []string, exactS
 	} else {
 		// In exactScopeMatch, keywords are explicitly treated as scopes
 		for _, k := range keywords {
-			lowerK := strings.ToLower(k)
+			lowerK := normalizeTerm(k)
 			validScopes[lowerK] = struct{}{}
 		}
 	}
+	i.addAncestorScopes(validScopes)
 	return validScopes
 }
 
 // findCandidates returns documents that match ANY of the search criteria (OR logic)
-func (i *Indexer) findCandidates(keywords []string, exactScopeMatch bool, validScopes map[string]struct{}) []*domain.Document {
+func (i *Indexer) findCandidates(terms []queryTerm, exactScopeMatch bool, validScopes map[string]struct{}) []*domain.Document {
 	var candidates []*domain.Document
 	seen := make(map[string]struct{})
 
@@ -241,10 +518,15 @@ func (i *Indexer) findCandidates(keywords []string, exactScopeMatch bool, validS
 	}
 
 	// 1. Matches by Scope (Implicit or Explicit)
-	// If we have valid scopes, everything in them is a candidate
-	for scope := range validScopes {
-		if docs, ok := i.ScopeIndex[scope]; ok {
-			for _, doc := range docs {
+	// If we have valid scopes, everything in them is a candidate; in inherit mode that
+	// includes the documents nested below them (the scope rule then drops other branches)
+	if i.ScopeMode == ScopeModeInherit {
+		for _, doc := range i.nestedDocuments(validScopes) {
+			addCandidate(doc)
+		}
+	} else {
+		for scope := range validScopes {
+			for _, doc := range i.ScopeIndex[scope] {
 				addCandidate(doc)
 			}
 		}
@@ -252,8 +534,8 @@ func (i *Indexer) findCandidates(keywords []string, exactScopeMatch bool, validS
 
 	// 2. Matches by Keyword (if allowed)
 	if !exactScopeMatch {
-		for _, term := range keywords {
-			k := strings.ToLower(term)
+		for _, term := range terms {
+			k := term.term
 
 			// Keyword Index
 			if docs, ok := i.KeywordIndex[k]; ok {
@@ -261,12 +543,25 @@ func (i *Indexer) findCandidates(keywords []string, exactScopeMatch bool, validS
 					addCandidate(doc)
 				}
 			}
+
+			// Full-Text Index (empty unless FullText is enabled)
+			for _, doc := range i.FullTextIndex[k] {
+				addCandidate(doc)
+			}
 		}
 	}
 
 	return candidates
 }
 
+// normalizeTerm lowercases a scope name and strips surrounding whitespace and punctuation
+// (e.g. "Go," -> "go"), keeping inner separators such as in "user-guide"
+func normalizeTerm(term string) string {
+	return strings.ToLower(strings.TrimFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
 // isSubset returns true if every scope in docScopes exists in validScopes
 // If docScopes is empty, it returns true (Root documents are global).
 func isSubset(docScopes []string, validScopes map[string]struct{}) bool {
@@ -283,6 +578,15 @@ func isSubset(docScopes []string, validScopes map[string]struct{}) bool {
 	return true
 }
 
+// sortedDocuments returns all documents ordered by ID
+func (i *Indexer) sortedDocuments() []*domain.Document {
+	docs := i.GetAll()
+	sort.Slice(docs, func(a, b int) bool {
+		return docs[a].ID < docs[b].ID
+	})
+	return docs
+}
+
 func (i *Indexer) GetAll() []*domain.Document {
 	docs := make([]*domain.Document, 0, len(i.Documents))
 	for _, doc := range i.Documents {
@@ -296,21 +600,39 @@ func (i *Indexer) GetErrors() []error {
 }
 
 func (i *Indexer) GetByID(id string) (*domain.Document, bool) {
+	return i.GetByIDContext(context.Background(), id)
+}
+
+// GetByIDContext is GetByID with a context that cancels fetching the body
+func (i *Indexer) GetByIDContext(ctx context.Context, id string) (*domain.Document, bool) {
 	doc, ok := i.Documents[id]
 	if !ok {
 		return nil, false
 	}
 
-	// Lazy Loading
-	if doc.Body == "" {
-		content, err := i.Provider.FetchContent(doc.Path)
-		if err == nil {
-			doc.Body = content
-		} else {
-			// Log error?
-			i.Logger.Error("Failed to fetch content for %s: %v", id, err)
-		}
+	// Lazy Loading (the body is only ever stored once, under the write lock).
+	// The fetch runs without the lock so a slow remote does not hold up searches.
+	i.mu.RLock()
+	body, loaded := i.bodies[id]
+	i.mu.RUnlock()
+	if loaded {
+		return withBody(doc, body), true
 	}
 
-	return doc, ok
+	content, err := i.fetchBody(ctx, doc)
+	if err != nil {
+		i.Logger.Error("Failed to fetch content for %s: %v", id, err)
+		return doc, true
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.storeBody(doc, content)
+	return withBody(doc, i.bodies[id]), true
+}
+
+// withBody returns a copy of the document carrying its body, leaving the shared document untouched
+func withBody(doc *domain.Document, body string) *domain.Document {
+	copied := *doc
+	copied.Body = body
+	return &copied
 }
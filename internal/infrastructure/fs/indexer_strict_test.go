@@ -5,6 +5,8 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/mew-ton/kex/internal/domain"
+
 	"github.com/mew-ton/kex/internal/infrastructure/logger"
 )
 
@@ -141,7 +143,7 @@ Content`
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := idx.Search(tt.keywords, nil, false)
+			got := idx.Search(domain.SearchQuery{Keywords: tt.keywords})
 
 			for _, exp := range tt.expected {
 				found := false
@@ -114,7 +114,7 @@ Content 2`
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := idx.Search(tt.keywords, nil, tt.exactScopeMatch)
+			got := idx.Search(domain.SearchQuery{Keywords: tt.keywords, ExactScopeMatch: tt.exactScopeMatch})
 			if len(got) != len(tt.wantIDs) {
 				t.Errorf("Search() got %d docs, want %d", len(got), len(tt.wantIDs))
 			}
@@ -221,7 +221,7 @@ func TestIndexer_ScopeFiltering(t *testing.T) {
 
 	// Case 1: Search [coding]
 	t.Run("Search coding", func(t *testing.T) {
-		got := idx.Search([]string{"coding"}, nil, true)
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"coding"}, ExactScopeMatch: true})
 
 		foundGo := false
 		for _, d := range got {
@@ -236,7 +236,7 @@ func TestIndexer_ScopeFiltering(t *testing.T) {
 
 	// Case 2: Search [coding, go]
 	t.Run("Search coding, go", func(t *testing.T) {
-		got := idx.Search([]string{"coding", "go"}, nil, true)
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"coding", "go"}, ExactScopeMatch: true})
 		foundCoding := false
 		foundGo := false
 		for _, d := range got {
@@ -255,3 +255,49 @@ func TestIndexer_ScopeFiltering(t *testing.T) {
 		}
 	})
 }
+
+func TestIndexer_Search_Ranking(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	docs := map[string]string{
+		"a-single.md": "---\ntitle: Naming\ndescription: Error messages should be lowercase\nkeywords: [naming]\n---\n",
+		"b-many.md":   "---\ntitle: Error Handling\ndescription: Wrap errors with context\nkeywords: [error, handling, wrap, context]\n---\n",
+		"c-none.md":   "---\ntitle: Logging\nkeywords: [logging]\n---\n",
+	}
+	for name, content := range docs {
+		if err := os.WriteFile(filepath.Join(tmpDir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := &logger.NoOpLogger{}
+	idx := New(NewLocalProvider(tmpDir, l), l)
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("it should order results by descending score", func(t *testing.T) {
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"error", "handling", "wrap"}})
+		if len(got) != 2 {
+			t.Fatalf("expected 2 results, got %d", len(got))
+		}
+		if got[0].ID != "b-many" || got[1].ID != "a-single" {
+			t.Errorf("unexpected order: %s, %s", got[0].ID, got[1].ID)
+		}
+		if got[0].Score <= got[1].Score {
+			t.Errorf("expected descending scores, got %f, %f", got[0].Score, got[1].Score)
+		}
+	})
+
+	t.Run("it should return the same order on every call", func(t *testing.T) {
+		first := idx.Search(domain.SearchQuery{Keywords: []string{"error", "logging", "naming"}})
+		for n := 0; n < 10; n++ {
+			again := idx.Search(domain.SearchQuery{Keywords: []string{"error", "logging", "naming"}})
+			for k := range first {
+				if first[k].ID != again[k].ID {
+					t.Fatalf("order changed between runs: %s vs %s", first[k].ID, again[k].ID)
+				}
+			}
+		}
+	})
+}
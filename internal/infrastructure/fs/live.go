@@ -0,0 +1,96 @@
+package fs
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// LiveRepository serves an Indexer that can be rebuilt while requests are in flight.
+// Each reload builds a fresh Indexer and swaps it in; requests already running keep
+// using the Indexer they started with.
+type LiveRepository struct {
+	build func() (*Indexer, error)
+
+	mu      sync.RWMutex
+	current *Indexer
+}
+
+// NewLiveRepository creates a repository around an already loaded Indexer.
+// build is called on every Reload and must return a loaded (and validated) Indexer.
+func NewLiveRepository(current *Indexer, build func() (*Indexer, error)) *LiveRepository {
+	return &LiveRepository{
+		build:   build,
+		current: current,
+	}
+}
+
+// Current returns the Indexer serving requests
+func (r *LiveRepository) Current() *Indexer {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current
+}
+
+// Load rebuilds the index (see Reload)
+func (r *LiveRepository) Load() error {
+	_, err := r.Reload()
+	return err
+}
+
+// Reload builds a new Indexer and swaps it in.
+// On failure the current Indexer keeps serving. It reports whether the document list
+// (IDs, titles or descriptions) changed; bodies are not compared, so subscribed
+// resources have to be checked after every reload.
+func (r *LiveRepository) Reload() (bool, error) {
+	next, err := r.build()
+	if err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	previous := r.current
+	r.current = next
+	r.mu.Unlock()
+
+	return previous == nil || documentsChanged(previous.Documents, next.Documents), nil
+}
+
+func (r *LiveRepository) GetAll() []*domain.Document {
+	return r.Current().GetAll()
+}
+
+func (r *LiveRepository) GetErrors() []error {
+	return r.Current().GetErrors()
+}
+
+func (r *LiveRepository) GetByID(id string) (*domain.Document, bool) {
+	return r.Current().GetByID(id)
+}
+
+func (r *LiveRepository) Search(query domain.SearchQuery) []domain.SearchResult {
+	return r.Current().Search(query)
+}
+
+func (r *LiveRepository) GetByIDContext(ctx context.Context, id string) (*domain.Document, bool) {
+	return r.Current().GetByIDContext(ctx, id)
+}
+
+func (r *LiveRepository) SearchContext(ctx context.Context, query domain.SearchQuery) []domain.SearchResult {
+	return r.Current().SearchContext(ctx, query)
+}
+
+// documentsChanged compares what clients see in a document listing
+func documentsChanged(a, b map[string]*domain.Document) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for id, docA := range a {
+		docB, ok := b[id]
+		if !ok || docA.Title != docB.Title || docA.Description != docB.Description {
+			return true
+		}
+	}
+	return false
+}
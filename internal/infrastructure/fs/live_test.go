@@ -0,0 +1,78 @@
+package fs
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestLiveRepository_Reload(t *testing.T) {
+	docs := []*DocumentSchema{{ID: "a", Title: "Alpha", Keywords: []string{"alpha"}, Path: "a.md"}}
+	var buildErr error
+	build := func() (*Indexer, error) {
+		if buildErr != nil {
+			return nil, buildErr
+		}
+		idx := New(&MockProvider{
+			Documents: docs,
+			Content:   map[string]string{"a.md": "alpha body", "b.md": "beta body"},
+		}, &logger.NoOpLogger{})
+		idx.FullText = true
+		return idx, idx.Load()
+	}
+
+	first, err := build()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := NewLiveRepository(first, build)
+
+	t.Run("it should report no change when the document list is the same", func(t *testing.T) {
+		changed, err := repo.Reload()
+		if err != nil || changed {
+			t.Errorf("expected unchanged reload, got changed=%v err=%v", changed, err)
+		}
+	})
+
+	t.Run("it should swap in added documents", func(t *testing.T) {
+		docs = append(docs, &DocumentSchema{ID: "b", Title: "Beta", Keywords: []string{"beta"}, Path: "b.md"})
+		changed, err := repo.Reload()
+		if err != nil || !changed {
+			t.Fatalf("expected changed reload, got changed=%v err=%v", changed, err)
+		}
+		if _, ok := repo.GetByID("b"); !ok {
+			t.Error("expected b after reload")
+		}
+	})
+
+	t.Run("it should keep serving the previous index when a reload fails", func(t *testing.T) {
+		buildErr = errors.New("broken document")
+		defer func() { buildErr = nil }()
+		if _, err := repo.Reload(); err == nil {
+			t.Fatal("expected reload error")
+		}
+		if len(repo.GetAll()) != 2 {
+			t.Errorf("expected previous 2 documents, got %d", len(repo.GetAll()))
+		}
+	})
+
+	t.Run("it should serve requests while reloading", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for n := 0; n < 4; n++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				repo.Search(domain.SearchQuery{Keywords: []string{"body"}})
+				repo.GetByID("a")
+			}()
+			go func() {
+				defer wg.Done()
+				repo.Reload()
+			}()
+		}
+		wg.Wait()
+	})
+}
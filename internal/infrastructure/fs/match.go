@@ -0,0 +1,230 @@
+package fs
+
+import (
+	"sort"
+)
+
+// MatchOptions controls how query terms are expanded against the index vocabulary
+type MatchOptions struct {
+	Prefix   bool // "test" also matches "testing"
+	Fuzzy    bool // "typscript" also matches "typescript"
+	MaxEdits int  // Upper bound of the edit distance for fuzzy matching (0 = DefaultMaxEdits)
+}
+
+const (
+	// DefaultMaxEdits is the fuzzy edit distance bound used when none is configured
+	DefaultMaxEdits = 2
+
+	minPrefixLength = 3   // Shorter prefixes (e.g. "go") would match too much
+	prefixBoost     = 0.8 // Score multiplier for prefix expansions
+	fuzzyBoost      = 0.6 // Score multiplier for fuzzy expansions
+	exactBoost      = 1.0 // Score multiplier for exact matches
+	fuzzyMinLength  = 4   // Terms shorter than this are never fuzzy matched
+	fuzzyLongLength = 8   // Terms at least this long may use two edits
+)
+
+// queryTerm is an index term a query resolved to, with the boost of the match kind
+type queryTerm struct {
+	term  string
+	boost float64
+}
+
+// expand resolves a query term to the index terms it matches.
+// The exact term is always included; prefix and fuzzy expansions are added when enabled.
+func (v *vocabulary) expand(term string, opts MatchOptions) []queryTerm {
+	matches := map[string]float64{term: exactBoost}
+	add := func(t string, boost float64) {
+		if boost > matches[t] {
+			matches[t] = boost
+		}
+	}
+
+	if opts.Prefix && len([]rune(term)) >= minPrefixLength {
+		for _, t := range v.trie.withPrefix(term) {
+			add(t, prefixBoost)
+		}
+	}
+
+	if opts.Fuzzy {
+		if edits := allowedEdits(term, opts.MaxEdits); edits > 0 {
+			for _, t := range v.bk.search(term, edits) {
+				add(t, fuzzyBoost)
+			}
+		}
+	}
+
+	expanded := make([]queryTerm, 0, len(matches))
+	for t, boost := range matches {
+		expanded = append(expanded, queryTerm{term: t, boost: boost})
+	}
+	sort.Slice(expanded, func(a, b int) bool { return expanded[a].term < expanded[b].term })
+	return expanded
+}
+
+// allowedEdits scales the edit distance with term length so short terms stay precise
+func allowedEdits(term string, maxEdits int) int {
+	if maxEdits <= 0 {
+		maxEdits = DefaultMaxEdits
+	}
+	n := len([]rune(term))
+	edits := 0
+	switch {
+	case n >= fuzzyLongLength:
+		edits = 2
+	case n >= fuzzyMinLength:
+		edits = 1
+	}
+	if edits > maxEdits {
+		edits = maxEdits
+	}
+	return edits
+}
+
+// vocabulary holds every indexed term for prefix and fuzzy lookups
+type vocabulary struct {
+	terms map[string]struct{}
+	trie  *trieNode
+	bk    *bkTree
+}
+
+func newVocabulary() *vocabulary {
+	return &vocabulary{
+		terms: make(map[string]struct{}),
+		trie:  newTrieNode(),
+		bk:    &bkTree{},
+	}
+}
+
+func (v *vocabulary) add(term string) {
+	if _, ok := v.terms[term]; ok {
+		return
+	}
+	v.terms[term] = struct{}{}
+	v.trie.insert(term)
+	v.bk.insert(term)
+}
+
+// trieNode is a prefix tree over index terms
+type trieNode struct {
+	children map[rune]*trieNode
+	terminal bool
+}
+
+func newTrieNode() *trieNode {
+	return &trieNode{children: make(map[rune]*trieNode)}
+}
+
+func (n *trieNode) insert(term string) {
+	node := n
+	for _, r := range term {
+		child, ok := node.children[r]
+		if !ok {
+			child = newTrieNode()
+			node.children[r] = child
+		}
+		node = child
+	}
+	node.terminal = true
+}
+
+// withPrefix returns all terms starting with prefix
+func (n *trieNode) withPrefix(prefix string) []string {
+	node := n
+	for _, r := range prefix {
+		child, ok := node.children[r]
+		if !ok {
+			return nil
+		}
+		node = child
+	}
+
+	var terms []string
+	var walk func(node *trieNode, path []rune)
+	walk = func(node *trieNode, path []rune) {
+		if node.terminal {
+			terms = append(terms, string(path))
+		}
+		for r, child := range node.children {
+			walk(child, append(path, r))
+		}
+	}
+	walk(node, []rune(prefix))
+	return terms
+}
+
+// bkTree is a Burkhard-Keller tree for bounded edit-distance lookups
+type bkTree struct {
+	root *bkNode
+}
+
+type bkNode struct {
+	term     string
+	children map[int]*bkNode
+}
+
+func (t *bkTree) insert(term string) {
+	if t.root == nil {
+		t.root = &bkNode{term: term, children: make(map[int]*bkNode)}
+		return
+	}
+	node := t.root
+	for {
+		d := levenshtein(term, node.term)
+		if d == 0 {
+			return
+		}
+		child, ok := node.children[d]
+		if !ok {
+			node.children[d] = &bkNode{term: term, children: make(map[int]*bkNode)}
+			return
+		}
+		node = child
+	}
+}
+
+// search returns all terms within maxDist edits of term
+func (t *bkTree) search(term string, maxDist int) []string {
+	if t.root == nil {
+		return nil
+	}
+	var results []string
+	stack := []*bkNode{t.root}
+	for len(stack) > 0 {
+		node := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := levenshtein(term, node.term)
+		if d <= maxDist {
+			results = append(results, node.term)
+		}
+		// Triangle inequality: only subtrees within [d-maxDist, d+maxDist] can match
+		for dist, child := range node.children {
+			if dist >= d-maxDist && dist <= d+maxDist {
+				stack = append(stack, child)
+			}
+		}
+	}
+	return results
+}
+
+// levenshtein returns the edit distance between two strings (rune-aware)
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
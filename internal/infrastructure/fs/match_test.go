@@ -0,0 +1,152 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"typescript", "typescript", 0},
+		{"typscript", "typescript", 1},
+		{"tpyescript", "typescript", 2},
+		{"", "go", 2},
+		{"日本語", "日本", 1},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestVocabulary_Expand(t *testing.T) {
+	v := newVocabulary()
+	for _, term := range []string{"test", "testing", "tests", "typescript", "go", "do"} {
+		v.add(term)
+	}
+
+	termsOf := func(qts []queryTerm) []string {
+		var terms []string
+		for _, qt := range qts {
+			terms = append(terms, qt.term)
+		}
+		sort.Strings(terms)
+		return terms
+	}
+
+	tests := []struct {
+		name string
+		term string
+		opts MatchOptions
+		want []string
+	}{
+		{
+			name: "it should only match exactly by default",
+			term: "test",
+			want: []string{"test"},
+		},
+		{
+			name: "it should expand prefixes when enabled",
+			term: "test",
+			opts: MatchOptions{Prefix: true},
+			want: []string{"test", "testing", "tests"},
+		},
+		{
+			name: "it should not expand very short prefixes",
+			term: "go",
+			opts: MatchOptions{Prefix: true},
+			want: []string{"go"},
+		},
+		{
+			name: "it should tolerate typos when fuzzy is enabled",
+			term: "typscript",
+			opts: MatchOptions{Fuzzy: true},
+			want: []string{"typescript", "typscript"},
+		},
+		{
+			name: "it should not fuzzy match short terms",
+			term: "go",
+			opts: MatchOptions{Fuzzy: true},
+			want: []string{"go"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := termsOf(v.expand(tt.term, tt.opts)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expand() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexer_Search_MatchOptions(t *testing.T) {
+	tmpDir := t.TempDir()
+	doc := "---\ntitle: Prefer TypeScript, strictly\nkeywords: [goroutines]\n---\n"
+	if err := os.WriteFile(filepath.Join(tmpDir, "ts.md"), []byte(doc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &logger.NoOpLogger{}
+	idx := New(NewLocalProvider(tmpDir, l), l)
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	enabled, disabled := true, false
+
+	tests := []struct {
+		name      string
+		defaults  MatchOptions
+		query     domain.SearchQuery
+		wantFound bool
+	}{
+		{
+			name:      "it should match titles regardless of trailing punctuation",
+			query:     domain.SearchQuery{Keywords: []string{"typescript"}},
+			wantFound: true,
+		},
+		{
+			name:      "it should not prefix match unless requested",
+			query:     domain.SearchQuery{Keywords: []string{"gorout"}},
+			wantFound: false,
+		},
+		{
+			name:      "it should prefix match when requested per query",
+			query:     domain.SearchQuery{Keywords: []string{"gorout"}, Prefix: &enabled},
+			wantFound: true,
+		},
+		{
+			name:      "it should fuzzy match when enabled by default",
+			defaults:  MatchOptions{Fuzzy: true},
+			query:     domain.SearchQuery{Keywords: []string{"typscript"}},
+			wantFound: true,
+		},
+		{
+			name:      "it should let the query disable a default",
+			defaults:  MatchOptions{Fuzzy: true},
+			query:     domain.SearchQuery{Keywords: []string{"typscript"}, Fuzzy: &disabled},
+			wantFound: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			idx.Matching = tt.defaults
+			got := idx.Search(tt.query)
+			if found := len(got) == 1 && got[0].ID == "ts"; found != tt.wantFound {
+				t.Errorf("found = %v, want %v", found, tt.wantFound)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package fs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// addendumHeading separates a referenced body from the local body extending it
+const addendumHeading = "## Project Addendum"
+
+// applyOverrides hides referenced documents overridden by local ones.
+// The first document overriding an ID wins; unknown IDs are logged and ignored
+// (the reference may be unreachable).
+func (i *Indexer) applyOverrides() {
+	for _, doc := range i.sortedDocuments() {
+		if doc.Overrides == "" {
+			continue
+		}
+		base, ok := i.Documents[doc.Overrides]
+		if !ok || base == doc {
+			i.Logger.Error("%s overrides unknown document %s", doc.ID, doc.Overrides)
+			continue
+		}
+		if base.OverriddenBy != "" {
+			i.Logger.Error("%s overrides %s, which is already overridden by %s", doc.ID, base.ID, base.OverriddenBy)
+			continue
+		}
+		base.OverriddenBy = doc.ID
+		if doc.EffectiveOverrideMode() == domain.OverrideExtend {
+			i.extends[doc.ID] = base
+			if doc.Size > 0 && base.Size > 0 {
+				doc.Size += base.Size + len(addendumHeading)
+			}
+		}
+	}
+}
+
+// applyWaivers marks the waived documents with their reason
+func (i *Indexer) applyWaivers() {
+	for id, reason := range i.Waivers {
+		doc, ok := i.Documents[id]
+		if !ok {
+			i.Logger.Error("Waiver for unknown document %s", id)
+			continue
+		}
+		doc.Waiver = reason
+	}
+}
+
+// fetchBody fetches the document body. A document extending a referenced one is served
+// as the referenced body followed by its own body as an addendum.
+func (i *Indexer) fetchBody(ctx context.Context, doc *domain.Document) (string, error) {
+	content, err := i.Provider.FetchContent(ctx, doc.Path)
+	if err != nil {
+		return "", err
+	}
+	base, ok := i.extends[doc.ID]
+	if !ok {
+		return content, nil
+	}
+
+	i.mu.RLock()
+	baseBody := i.bodies[base.ID]
+	i.mu.RUnlock()
+	if baseBody == "" {
+		baseBody, err = i.Provider.FetchContent(ctx, base.Path)
+		if err != nil {
+			// The addendum alone is better than nothing
+			i.Logger.Error("Failed to fetch content for %s (extended by %s): %v", base.ID, doc.ID, err)
+			return content, nil
+		}
+	}
+	return strings.TrimRight(baseBody, "\n") + "\n\n" + addendumHeading + "\n\n" + content, nil
+}
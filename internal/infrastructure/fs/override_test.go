@@ -0,0 +1,75 @@
+package fs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Overrides(t *testing.T) {
+	load := func(waivers map[string]string) *Indexer {
+		local := &MockProvider{
+			Documents: []*DocumentSchema{
+				{ID: "naming", Title: "Project Naming", Keywords: []string{"naming"}, Path: "naming.md", Overrides: "org:naming"},
+				{ID: "errors", Title: "Project Errors", Keywords: []string{"errors"}, Path: "errors.md", Overrides: "org:errors", OverrideMode: "extend"},
+			},
+			Content: map[string]string{"naming.md": "Use snake_case.", "errors.md": "Also log the request ID.\n"},
+		}
+		remote := &MockProvider{
+			Documents: []*DocumentSchema{
+				{ID: "org:naming", Title: "Org Naming", Keywords: []string{"naming"}, Path: "naming.md"},
+				{ID: "org:errors", Title: "Org Errors", Keywords: []string{"errors"}, Path: "errors.md"},
+				{ID: "org:logging", Title: "Org Logging", Keywords: []string{"logging"}, Path: "logging.md"},
+			},
+			Content: map[string]string{"naming.md": "Use camelCase.", "errors.md": "## Wrapping\n\nWrap errors.\n"},
+		}
+		idx := New(NewCompositeProvider([]DocumentProvider{local, remote}), &logger.NoOpLogger{})
+		idx.Waivers = waivers
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		return idx
+	}
+
+	ids := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	t.Run("it should serve the local variant instead of the overridden document", func(t *testing.T) {
+		idx := load(nil)
+		if got, want := ids(idx.Search(domain.SearchQuery{Keywords: []string{"naming"}})), []string{"naming"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		if doc, _ := idx.GetByID("org:naming"); doc.OverriddenBy != "naming" {
+			t.Errorf("expected org:naming to be overridden by naming, got %q", doc.OverriddenBy)
+		}
+		if doc, _ := idx.GetByID("naming"); doc.Body != "Use snake_case." {
+			t.Errorf("expected the local body, got %q", doc.Body)
+		}
+	})
+
+	t.Run("it should append the local body to an extended document", func(t *testing.T) {
+		idx := load(nil)
+		doc, _ := idx.GetByID("errors")
+		want := "## Wrapping\n\nWrap errors.\n\n" + addendumHeading + "\n\nAlso log the request ID.\n"
+		if doc.Body != want {
+			t.Errorf("expected %q, got %q", want, doc.Body)
+		}
+	})
+
+	t.Run("it should hide waived documents from search", func(t *testing.T) {
+		idx := load(map[string]string{"org:logging": "We use the platform logger"})
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"logging"}}); len(got) != 0 {
+			t.Errorf("expected no results, got %v", ids(got))
+		}
+		if doc, ok := idx.GetByID("org:logging"); !ok || doc.Waiver != "We use the platform logger" {
+			t.Errorf("expected a readable waived document, got %v", doc)
+		}
+	})
+}
@@ -11,6 +11,9 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
+// PromptsDir is the top-level directory of a source whose documents default to kind: prompt
+const PromptsDir = "prompts"
+
 // ParseDocument reads a markdown file and parses its frontmatter
 func ParseDocument(path, root string) (*domain.Document, error) {
 	// Read file content
@@ -25,17 +28,30 @@ func ParseDocument(path, root string) (*domain.Document, error) {
 		return nil, fmt.Errorf("failed to read file: %w", err)
 	}
 
+	return parseDocumentFile(path, root, content)
+}
+
+// parseDocumentFile parses already-read file content, deriving scopes and ID from the path
+func parseDocumentFile(path, root string, content []byte) (*domain.Document, error) {
 	doc, err := parseDocumentContent(path, content)
 	if err != nil {
 		return nil, err
 	}
 
-	doc.Scopes, err = deriveScopes(path, root)
+	pathScopes, err := deriveScopes(path, root)
 	if err != nil {
 		// Fallback or just ignore error? deriveScopes handles fallback internally if needed
 	}
 
-	doc.ID = generateID(path, doc.Scopes)
+	// The ID always follows the file location, even when scopes are set in frontmatter
+	doc.ID = generateID(path, pathScopes)
+	if len(doc.Scopes) == 0 {
+		doc.Scopes = pathScopes
+	}
+	// Files in the prompts directory are prompts unless their frontmatter says otherwise
+	if doc.Kind == "" && len(pathScopes) > 0 && pathScopes[0] == PromptsDir {
+		doc.Kind = domain.KindPrompt
+	}
 
 	return doc, nil
 }
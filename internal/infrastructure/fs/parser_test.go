@@ -3,7 +3,10 @@ package fs
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
 )
 
 func TestParseDocumentContent(t *testing.T) {
@@ -97,3 +100,108 @@ Body`
 		}
 	})
 }
+
+func TestParseDocument_Scopes(t *testing.T) {
+	rootDir := t.TempDir()
+	dir := filepath.Join(rootDir, "coding", "go")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	t.Run("it should derive scopes from directories", func(t *testing.T) {
+		doc, err := ParseDocument(write("derived.md", "---\ntitle: Derived\n---\nbody"), rootDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(doc.Scopes, []string{"coding", "go"}) || doc.ID != "coding.go.derived" {
+			t.Errorf("unexpected scopes %v / ID %s", doc.Scopes, doc.ID)
+		}
+	})
+
+	t.Run("it should let frontmatter override scopes but keep the path-based ID", func(t *testing.T) {
+		doc, err := ParseDocument(write("override.md", "---\ntitle: Override\nscopes: [vcs, git]\n---\nbody"), rootDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(doc.Scopes, []string{"vcs", "git"}) || doc.ID != "coding.go.override" {
+			t.Errorf("unexpected scopes %v / ID %s", doc.Scopes, doc.ID)
+		}
+	})
+}
+
+func TestParseDocument_Kind(t *testing.T) {
+	rootDir := t.TempDir()
+	write := func(rel, content string) string {
+		path := filepath.Join(rootDir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	tests := []struct {
+		name     string
+		rel      string
+		content  string
+		expected domain.DocumentKind
+	}{
+		{
+			name:     "it should treat files in the prompts directory as prompts",
+			rel:      "prompts/review.md",
+			content:  "---\ntitle: Review\narguments:\n  - name: file\n    required: true\n---\nReview {{.file}}",
+			expected: domain.KindPrompt,
+		},
+		{
+			name:     "it should keep an explicit kind in the prompts directory",
+			rel:      "prompts/guide.md",
+			content:  "---\ntitle: Guide\nkind: guideline\n---\nbody",
+			expected: domain.KindGuideline,
+		},
+		{
+			name:     "it should read the kind from frontmatter elsewhere",
+			rel:      "coding/plan.md",
+			content:  "---\ntitle: Plan\nkind: prompt\n---\nPlan",
+			expected: domain.KindPrompt,
+		},
+		{
+			name:     "it should leave other documents without a kind",
+			rel:      "coding/prompts/errors.md",
+			content:  "---\ntitle: Errors\n---\nbody",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := ParseDocument(write(tt.rel, tt.content), rootDir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if doc.Kind != tt.expected {
+				t.Errorf("expected kind %q, got %q", tt.expected, doc.Kind)
+			}
+		})
+	}
+
+	t.Run("it should parse prompt arguments", func(t *testing.T) {
+		doc, err := ParseDocument(filepath.Join(rootDir, "prompts", "review.md"), rootDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected := []domain.PromptArgument{{Name: "file", Required: true}}
+		if !reflect.DeepEqual(doc.Arguments, expected) {
+			t.Errorf("expected arguments %v, got %v", expected, doc.Arguments)
+		}
+	})
+}
@@ -1,9 +1,25 @@
 package fs
 
+import "context"
+
 // DocumentProvider defines the strategy for loading and fetching documents
 type DocumentProvider interface {
 	// Load retrieves the index schema from the source
 	Load() (*IndexSchema, []error)
-	// FetchContent retrieves the raw content for a specific path
-	FetchContent(path string) (string, error)
+	// FetchContent retrieves the raw content for a specific path; the context cancels a remote fetch
+	FetchContent(ctx context.Context, path string) (string, error)
+}
+
+// NamedProvider is implemented by providers that can tell where their documents
+// come from, so messages can name the source of a document.
+type NamedProvider interface {
+	// SourceName is the local root or URL of the provider
+	SourceName() string
+}
+
+// LocalContentProvider is implemented by providers that can read content cheaply
+// (e.g. from the local filesystem), so bodies can be indexed eagerly at load time.
+// Providers that do not implement it are treated as remote.
+type LocalContentProvider interface {
+	IsLocal(path string) bool
 }
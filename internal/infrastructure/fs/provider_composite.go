@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 	"strings"
@@ -39,21 +40,47 @@ func (c *CompositeProvider) Load() (*IndexSchema, []error) {
 			continue
 		}
 
+		source := fmt.Sprintf("source %d", i)
+		if named, ok := p.(NamedProvider); ok {
+			source = named.SourceName()
+		}
 		for _, doc := range schema.Documents {
 			// Prefix path with provider index
+			doc.Source = source
+			doc.SourcePath = doc.Path
 			doc.Path = fmt.Sprintf("%d:%s", i, doc.Path)
 			combinedSchema.Documents = append(combinedSchema.Documents, doc)
 		}
+		combinedSchema.Synonyms = mergeSynonyms(combinedSchema.Synonyms, schema.Synonyms)
 	}
 
 	return combinedSchema, allErrors
 }
 
 // FetchContent routes the request to the correct provider based on the path prefix.
-func (c *CompositeProvider) FetchContent(path string) (string, error) {
+func (c *CompositeProvider) FetchContent(ctx context.Context, path string) (string, error) {
+	provider, actualPath, err := c.route(path)
+	if err != nil {
+		return "", err
+	}
+	return provider.FetchContent(ctx, actualPath)
+}
+
+// IsLocal reports whether the provider owning the path serves local content.
+func (c *CompositeProvider) IsLocal(path string) bool {
+	provider, actualPath, err := c.route(path)
+	if err != nil {
+		return false
+	}
+	local, ok := provider.(LocalContentProvider)
+	return ok && local.IsLocal(actualPath)
+}
+
+// route resolves a prefixed path (e.g. "0:path/to/doc.md") to its provider and original path.
+func (c *CompositeProvider) route(path string) (DocumentProvider, string, error) {
 	parts := strings.SplitN(path, ":", 2)
 	if len(parts) != 2 {
-		return "", fmt.Errorf("invalid composite path format: %s", path)
+		return nil, "", fmt.Errorf("invalid composite path format: %s", path)
 	}
 
 	indexStr := parts[0]
@@ -61,12 +88,12 @@ func (c *CompositeProvider) FetchContent(path string) (string, error) {
 
 	index, err := strconv.Atoi(indexStr)
 	if err != nil {
-		return "", fmt.Errorf("invalid provider index in path: %s", path)
+		return nil, "", fmt.Errorf("invalid provider index in path: %s", path)
 	}
 
 	if index < 0 || index >= len(c.Providers) {
-		return "", fmt.Errorf("provider index out of range: %d", index)
+		return nil, "", fmt.Errorf("provider index out of range: %d", index)
 	}
 
-	return c.Providers[index].FetchContent(actualPath)
+	return c.Providers[index], actualPath, nil
 }
@@ -1,8 +1,13 @@
 package fs
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
 )
 
 // MockProvider is a simple mock for DocumentProvider
@@ -19,7 +24,7 @@ func (m *MockProvider) Load() (*IndexSchema, []error) {
 	return &IndexSchema{Documents: m.Documents}, nil
 }
 
-func (m *MockProvider) FetchContent(path string) (string, error) {
+func (m *MockProvider) FetchContent(_ context.Context, path string) (string, error) {
 	if content, ok := m.Content[path]; ok {
 		return content, nil
 	}
@@ -70,6 +75,20 @@ func TestCompositeProvider_Load(t *testing.T) {
 	if !foundDoc1 || !foundDoc2 {
 		t.Error("missing documents in composite schema")
 	}
+
+	t.Run("it should record the source and the unprefixed path of each document", func(t *testing.T) {
+		local := NewLocalProvider(t.TempDir(), &logger.NoOpLogger{})
+		if err := os.WriteFile(filepath.Join(local.Root, "doc3.md"), []byte("---\ntitle: Doc 3\n---\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		schema, _ := NewCompositeProvider([]DocumentProvider{&MockProvider{Documents: []*DocumentSchema{{ID: "doc1", Path: "doc1.md"}}}, local}).Load()
+		if len(schema.Documents) != 2 || schema.Documents[0].Source != "source 0" || schema.Documents[1].Source != local.Root {
+			t.Errorf("unexpected sources %+v", schema.Documents)
+		}
+		if schema.Documents[0].SourcePath != "doc1.md" || schema.Documents[1].SourcePath != "doc3.md" {
+			t.Errorf("expected the paths within each source, got %q and %q", schema.Documents[0].SourcePath, schema.Documents[1].SourcePath)
+		}
+	})
 }
 
 func TestCompositeProvider_FetchContent(t *testing.T) {
@@ -98,7 +117,7 @@ func TestCompositeProvider_FetchContent(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := c.FetchContent(tt.path)
+			got, err := c.FetchContent(context.Background(), tt.path)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("CompositeProvider.FetchContent() error = %v, wantErr %v", err, tt.wantErr)
 				return
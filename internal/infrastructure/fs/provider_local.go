@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 	"os"
@@ -12,8 +13,9 @@ import (
 )
 
 type LocalProvider struct {
-	Root   string
-	Logger logger.Logger
+	Root     string
+	Logger   logger.Logger
+	CacheDir string // Where parsed documents are cached between runs ("" = no cache)
 }
 
 func (l *LocalProvider) Validate() error {
@@ -43,45 +45,106 @@ func (l *LocalProvider) Load() (*IndexSchema, []error) {
 		return nil, []error{err}
 	}
 
+	synonyms, err := loadSynonymsFile(filepath.Join(l.Root, SynonymsFileName))
+	if err != nil {
+		errs = append(errs, err)
+	}
+	schema.Synonyms = synonyms
+
+	var cache *localCache
+	if l.CacheDir != "" {
+		cache = openLocalCache(l.CacheDir, l.Root)
+	}
+
 	for _, path := range paths {
-		doc, err := ParseDocument(path, l.Root)
+		sd, err := l.loadDocument(path, cache)
 		if err != nil {
-			// We might want to handle errors differently (collect them?),
-			// but Provider interface signature simply returns error for Load.
-			// The original Indexer collected errors.
-			// Let's modify behavior: Skip invalid files but log?
-			// Or just return minimal schema and let Indexer validate?
-			// ParseDocument returns error if frontmatter is invalid.
-			// We should probably skip invalid docs here or return error.
-			// Original logic: "i.Errors = append..."
-			// Let's skip and log formatted error to stderr for now?
-			// Better: return what we can.
+			// Invalid files are skipped; the error is reported to the caller
 			errs = append(errs, err)
 			continue
 		}
+		schema.Documents = append(schema.Documents, sd)
+	}
+
+	if cache != nil {
+		cache.save()
+	}
 
-		// Default to Adopted if status is missing in local files
-		if doc.Status == "" {
-			doc.Status = domain.StatusAdopted
+	return schema, errs
+}
+
+// loadDocument parses a markdown file into its schema entry, reusing the cache when the file is unchanged
+func (l *LocalProvider) loadDocument(path string, cache *localCache) (*DocumentSchema, error) {
+	relPath, _ := filepath.Rel(l.Root, path)
+
+	info, statErr := os.Stat(path)
+	if cache != nil && statErr == nil {
+		if e := cache.lookup(relPath, info); e != nil {
+			return e.result()
 		}
+	}
 
-		relPath, _ := filepath.Rel(l.Root, path)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
 
-		schema.Documents = append(schema.Documents, &DocumentSchema{
-			ID:          doc.ID,
-			Title:       doc.Title,
-			Description: doc.Description,
-			Keywords:    doc.Keywords,
-			Scopes:      doc.Scopes,
-			Status:      string(doc.Status),
-			Path:        relPath, // Relative to Root
-		})
+	hash := contentHash(content)
+	if cache != nil && statErr == nil {
+		if e := cache.lookupContent(relPath, info, hash); e != nil {
+			return e.result()
+		}
 	}
 
-	return schema, errs
+	sd, parseErr := l.parseDocument(path, relPath, content)
+	if cache != nil && statErr == nil {
+		e := &localCacheEntry{ModTime: info.ModTime().UnixNano(), Size: info.Size(), Hash: hash, Document: sd}
+		if parseErr != nil {
+			e.Error = parseErr.Error()
+		}
+		cache.store(relPath, e)
+		return e.result()
+	}
+	if sd != nil {
+		sd.Hash = hash
+	}
+	return sd, parseErr
 }
 
-func (l *LocalProvider) FetchContent(path string) (string, error) {
+func (l *LocalProvider) parseDocument(path, relPath string, content []byte) (*DocumentSchema, error) {
+	doc, err := parseDocumentFile(path, l.Root, content)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default to Adopted if status is missing in local files
+	if doc.Status == "" {
+		doc.Status = domain.StatusAdopted
+	}
+
+	return &DocumentSchema{
+		ID:           doc.ID,
+		Kind:         string(doc.Kind),
+		Title:        doc.Title,
+		Description:  doc.Description,
+		Keywords:     doc.Keywords,
+		Scopes:       doc.Scopes,
+		AppliesTo:    doc.AppliesTo,
+		Related:      doc.Related,
+		Supersedes:   doc.Supersedes,
+		Requires:     doc.Requires,
+		ReplacedBy:   doc.ReplacedBy,
+		Overrides:    doc.Overrides,
+		OverrideMode: string(doc.OverrideMode),
+		Status:       string(doc.Status),
+		Severity:     string(doc.Severity),
+		Arguments:    doc.Arguments,
+		Path:         relPath, // Relative to Root
+		Size:         len(doc.Body),
+	}, nil
+}
+
+func (l *LocalProvider) FetchContent(_ context.Context, path string) (string, error) {
 	fullPath := filepath.Join(l.Root, path)
 
 	if l.Logger != nil {
@@ -101,6 +164,16 @@ func (l *LocalProvider) FetchContent(path string) (string, error) {
 	return sContent, nil
 }
 
+// SourceName returns the root directory
+func (l *LocalProvider) SourceName() string {
+	return l.Root
+}
+
+// IsLocal reports that all content of this provider lives on the local filesystem
+func (l *LocalProvider) IsLocal(path string) bool {
+	return true
+}
+
 func (l *LocalProvider) collectMarkdownFiles() ([]string, error) {
 	var paths []string
 	err := filepath.WalkDir(l.Root, func(path string, d fs.DirEntry, err error) error {
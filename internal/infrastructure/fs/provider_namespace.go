@@ -0,0 +1,83 @@
+package fs
+
+import (
+	"context"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// NamespacedProvider prefixes the IDs of another provider's documents with a namespace
+// (e.g. "org:coding.go.naming"), so references cannot collide with other sources.
+// Relations to documents of the same provider are prefixed as well.
+type NamespacedProvider struct {
+	Provider  DocumentProvider
+	Namespace string
+}
+
+// NewNamespacedProvider wraps the provider, or returns it as is when the namespace is empty
+func NewNamespacedProvider(provider DocumentProvider, namespace string) DocumentProvider {
+	if namespace == "" {
+		return provider
+	}
+	return &NamespacedProvider{Provider: provider, Namespace: namespace}
+}
+
+func (n *NamespacedProvider) Load() (*IndexSchema, []error) {
+	schema, errs := n.Provider.Load()
+	if schema == nil {
+		return nil, errs
+	}
+
+	ids := make(map[string]struct{}, len(schema.Documents))
+	for _, doc := range schema.Documents {
+		ids[doc.ID] = struct{}{}
+	}
+
+	// Slices are rebuilt rather than rewritten, since providers may share them with their caches
+	prefix := func(refs []string) []string {
+		if len(refs) == 0 {
+			return refs
+		}
+		out := make([]string, len(refs))
+		for i, id := range refs {
+			out[i] = n.prefix(id, ids)
+		}
+		return out
+	}
+	for _, doc := range schema.Documents {
+		doc.ID = domain.NamespacedID(n.Namespace, doc.ID)
+		doc.Related = prefix(doc.Related)
+		doc.Supersedes = prefix(doc.Supersedes)
+		doc.Requires = prefix(doc.Requires)
+		if doc.ReplacedBy != "" {
+			doc.ReplacedBy = n.prefix(doc.ReplacedBy, ids)
+		}
+	}
+	return schema, errs
+}
+
+// prefix namespaces an ID when it refers to a document of this provider
+func (n *NamespacedProvider) prefix(id string, ids map[string]struct{}) string {
+	if _, ok := ids[id]; ok {
+		return domain.NamespacedID(n.Namespace, id)
+	}
+	return id
+}
+
+func (n *NamespacedProvider) FetchContent(ctx context.Context, path string) (string, error) {
+	return n.Provider.FetchContent(ctx, path)
+}
+
+// SourceName names the wrapped provider
+func (n *NamespacedProvider) SourceName() string {
+	if named, ok := n.Provider.(NamedProvider); ok {
+		return named.SourceName()
+	}
+	return n.Namespace
+}
+
+// IsLocal reports whether the wrapped provider serves the path locally
+func (n *NamespacedProvider) IsLocal(path string) bool {
+	local, ok := n.Provider.(LocalContentProvider)
+	return ok && local.IsLocal(path)
+}
@@ -0,0 +1,97 @@
+package fs
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestNamespacedProvider_Load(t *testing.T) {
+	shared := []string{"coding.go.errors", "coding.go.logging"}
+	inner := &MockProvider{Documents: []*DocumentSchema{
+		{ID: "coding.go.naming", Path: "coding/go/naming.md", Related: shared},
+		{ID: "coding.go.errors", Path: "coding/go/errors.md"},
+	}}
+
+	schema, errs := NewNamespacedProvider(inner, "org").Load()
+	if len(errs) > 0 {
+		t.Fatal(errs)
+	}
+
+	t.Run("it should prefix document IDs", func(t *testing.T) {
+		if got := schema.Documents[0].ID; got != "org:coding.go.naming" {
+			t.Errorf("expected org:coding.go.naming, got %s", got)
+		}
+	})
+
+	t.Run("it should prefix relations to documents of the same source only", func(t *testing.T) {
+		want := []string{"org:coding.go.errors", "coding.go.logging"}
+		if got := schema.Documents[0].Related; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		if shared[0] != "coding.go.errors" {
+			t.Error("expected the provider's slice to be left untouched")
+		}
+	})
+
+	t.Run("it should return the provider as is without a namespace", func(t *testing.T) {
+		if got := NewNamespacedProvider(inner, ""); got != DocumentProvider(inner) {
+			t.Errorf("expected the inner provider, got %T", got)
+		}
+	})
+}
+
+func TestIndexer_Load_DuplicateIDs(t *testing.T) {
+	local := func() *MockProvider {
+		return &MockProvider{Documents: []*DocumentSchema{{ID: "coding.go.naming", Title: "Local Naming", Path: "naming.md"}}}
+	}
+	remote := func() *MockProvider {
+		return &MockProvider{Documents: []*DocumentSchema{{ID: "coding.go.naming", Title: "Org Naming", Path: "naming.md"}}}
+	}
+
+	t.Run("it should keep the first document and warn about the collision", func(t *testing.T) {
+		idx := New(NewCompositeProvider([]DocumentProvider{local(), remote()}), &logger.NoOpLogger{})
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if doc, _ := idx.GetByID("coding.go.naming"); doc == nil || doc.Title != "Local Naming" {
+			t.Errorf("expected the local document to win, got %v", doc)
+		}
+		if len(idx.Errors) != 0 {
+			t.Errorf("expected no errors, got %v", idx.Errors)
+		}
+		want := `duplicate document ID "coding.go.naming": using naming.md from source 0, ignoring naming.md from source 1`
+		if len(idx.Warnings) != 1 || !strings.Contains(idx.Warnings[0].Error(), want) {
+			t.Errorf("expected a warning naming the winning source, got %v", idx.Warnings)
+		}
+	})
+
+	t.Run("it should report the collision as an error when IDs are strict", func(t *testing.T) {
+		idx := New(NewCompositeProvider([]DocumentProvider{local(), remote()}), &logger.NoOpLogger{})
+		idx.StrictIDs = true
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if len(idx.Errors) != 1 || !strings.Contains(idx.Errors[0].Error(), `duplicate document ID "coding.go.naming"`) {
+			t.Errorf("expected a duplicate ID error, got %v", idx.Errors)
+		}
+		if len(idx.Warnings) != 0 {
+			t.Errorf("expected no warnings, got %v", idx.Warnings)
+		}
+	})
+
+	t.Run("it should keep both documents when the reference is namespaced", func(t *testing.T) {
+		idx := New(NewCompositeProvider([]DocumentProvider{local(), NewNamespacedProvider(remote(), "org")}), &logger.NoOpLogger{})
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if len(idx.Errors) != 0 {
+			t.Errorf("expected no errors, got %v", idx.Errors)
+		}
+		if doc, ok := idx.GetByID("org:coding.go.naming"); !ok || doc.Title != "Org Naming" {
+			t.Errorf("expected org:coding.go.naming, got %v", doc)
+		}
+	})
+}
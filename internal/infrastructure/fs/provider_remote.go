@@ -1,6 +1,7 @@
 package fs
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -15,6 +16,8 @@ type RemoteProvider struct {
 	KexURL  string // Full URL to kex.json
 	Token   string // Optional Bearer Token
 	Logger  logger.Logger
+
+	CacheDir string // Where the last kex.json is kept for conditional requests ("" = no cache)
 }
 
 func (r *RemoteProvider) Validate() error {
@@ -59,6 +62,11 @@ func NewRemoteProvider(rootURL, token string, logger logger.Logger) *RemoteProvi
 	}
 }
 
+// SourceName returns the base URL
+func (r *RemoteProvider) SourceName() string {
+	return r.BaseURL
+}
+
 func (r *RemoteProvider) Load() (*IndexSchema, []error) {
 	req, err := http.NewRequest("GET", r.KexURL, nil)
 	if err != nil {
@@ -69,16 +77,44 @@ func (r *RemoteProvider) Load() (*IndexSchema, []error) {
 		req.Header.Set("Authorization", "Bearer "+r.Token)
 	}
 
+	// Revalidate the cached index instead of downloading it again
+	var cached *remoteCache
+	if r.CacheDir != "" {
+		cached = readRemoteCache(remoteCachePath(r.CacheDir, r.KexURL), r.KexURL)
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
 	if r.Logger != nil {
 		r.Logger.Info("[Network] Fetch Index: %s", r.KexURL)
 	}
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
+		if cached != nil {
+			// Offline: serve the last known index rather than failing to start
+			if r.Logger != nil {
+				r.Logger.Error("[Network] %s unreachable, using cached index: %v", r.KexURL, err)
+			}
+			return r.parseIndex(cached.Index)
+		}
 		return nil, []error{err}
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		if r.Logger != nil {
+			r.Logger.Info("[Network] Index not modified: %s", r.KexURL)
+		}
+		return r.parseIndex(cached.Index)
+	}
+
 	if resp.StatusCode != http.StatusOK {
 		return nil, []error{fmt.Errorf("failed to fetch kex.json: status %d", resp.StatusCode)}
 	}
@@ -88,21 +124,33 @@ func (r *RemoteProvider) Load() (*IndexSchema, []error) {
 		return nil, []error{err}
 	}
 
+	schema, errs := r.parseIndex(data)
+	if schema != nil && r.CacheDir != "" {
+		writeCacheFile(remoteCachePath(r.CacheDir, r.KexURL), &remoteCache{
+			URL:          r.KexURL,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			Index:        data,
+		})
+	}
+	return schema, errs
+}
+
+func (r *RemoteProvider) parseIndex(data []byte) (*IndexSchema, []error) {
 	schema := &IndexSchema{}
 	if err := json.Unmarshal(data, schema); err != nil {
 		return nil, []error{err}
 	}
-
 	return schema, nil
 }
 
-func (r *RemoteProvider) FetchContent(path string) (string, error) {
+func (r *RemoteProvider) FetchContent(ctx context.Context, path string) (string, error) {
 	url := path
 	if !strings.HasPrefix(path, "http") {
 		url = r.BaseURL + strings.TrimLeft(path, "/")
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,279 @@
+package fs
+
+import (
+	"strings"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// docSet is a set of document IDs
+type docSet map[string]struct{}
+
+// queryFields maps text query fields to the ranking field they are indexed under
+var queryFields = map[string]Field{
+	domain.FieldTitle:       FieldTitle,
+	domain.FieldDescription: FieldDescription,
+	domain.FieldKeyword:     FieldKeywords,
+	domain.FieldBody:        FieldBody,
+}
+
+// searchExpr evaluates a parsed query expression.
+// Matching documents are ranked by the BM25F score of the non-negated text terms,
+// blended with the query vector when set.
+func (i *Indexer) searchExpr(query domain.SearchQuery, queryVector []float32) []domain.SearchResult {
+	opts := i.matchOptions(query)
+	matched, ok := i.evaluate(query.Expr, opts)
+	if !ok {
+		// Nothing left to match (e.g. a query made only of stopwords)
+		return nil
+	}
+
+	positive := positiveTerms(query.Expr)
+	var plain []string
+	for _, t := range positive {
+		if t.Field == domain.FieldAny {
+			plain = append(plain, t.Value)
+		}
+	}
+	terms := i.resolveTerms(textValues(positive), opts)
+
+	// Explicit scope: and id: filters replace the automatic scope subset rule
+	var validScopes map[string]struct{}
+	if !targetsExplicitly(positive) {
+		validScopes = i.inferScopes(plain, query.Scopes, false)
+	}
+
+	// Documents targeting the caller's file bypass the scope rule and rank higher,
+	// but still have to match the expression
+	targets := i.fileTargets(query.FilePath)
+
+	// Retired documents are only returned when the query asks for a status
+	includeRetired := filtersStatus(positive)
+
+	var results []domain.SearchResult
+	for _, doc := range i.sortedDocuments() {
+		if _, ok := matched[doc.ID]; !ok {
+			continue
+		}
+		// Prompts are never searched
+		if doc.IsPrompt() || doc.IsHidden(includeRetired) {
+			continue
+		}
+		pattern, targeted := targets[doc.ID]
+		if !targeted && validScopes != nil && !i.scopeAllowed(doc.Scopes, validScopes) {
+			continue
+		}
+		result := i.newResult(doc, terms)
+		if targeted {
+			boostTarget(&result, pattern)
+		}
+		results = append(results, result)
+	}
+
+	i.blendScores(results, queryVector)
+	i.applySeverity(results)
+	sortResults(results)
+	return results
+}
+
+// evaluate returns the IDs of documents matching the node.
+// ok is false when the node places no constraint (e.g. a term made only of stopwords),
+// in which case it is skipped by the enclosing node.
+func (i *Indexer) evaluate(node domain.QueryNode, opts MatchOptions) (docSet, bool) {
+	switch n := node.(type) {
+	case domain.TermNode:
+		return i.evaluateTerm(n, opts)
+	case domain.AndNode:
+		var result docSet
+		for _, child := range n.Children {
+			matched, ok := i.evaluate(child, opts)
+			if !ok {
+				continue
+			}
+			if result == nil {
+				result = matched
+				continue
+			}
+			for id := range result {
+				if _, ok := matched[id]; !ok {
+					delete(result, id)
+				}
+			}
+		}
+		return result, result != nil
+	case domain.OrNode:
+		var result docSet
+		for _, child := range n.Children {
+			matched, ok := i.evaluate(child, opts)
+			if !ok {
+				continue
+			}
+			if result == nil {
+				result = docSet{}
+			}
+			for id := range matched {
+				result[id] = struct{}{}
+			}
+		}
+		return result, result != nil
+	case domain.NotNode:
+		excluded, ok := i.evaluate(n.Child, opts)
+		if !ok {
+			return nil, false
+		}
+		result := docSet{}
+		for id := range i.Documents {
+			if _, ok := excluded[id]; !ok {
+				result[id] = struct{}{}
+			}
+		}
+		return result, true
+	}
+	return docSet{}, true
+}
+
+// evaluateTerm matches a single "field:value" clause (ok = false when the value has no terms to match)
+func (i *Indexer) evaluateTerm(n domain.TermNode, opts MatchOptions) (docSet, bool) {
+	result := docSet{}
+
+	switch n.Field {
+	case domain.FieldID:
+		if _, ok := i.Documents[n.Value]; ok {
+			result[n.Value] = struct{}{}
+		}
+		return result, true
+	case domain.FieldStatus:
+		for id, doc := range i.Documents {
+			if strings.EqualFold(string(doc.Status), n.Value) {
+				result[id] = struct{}{}
+			}
+		}
+		return result, true
+	case domain.FieldSeverity:
+		for id, doc := range i.Documents {
+			if strings.EqualFold(string(doc.EffectiveSeverity()), n.Value) {
+				result[id] = struct{}{}
+			}
+		}
+		return result, true
+	case domain.FieldScope:
+		for _, alt := range i.synonyms.expand(n.Value) {
+			for _, doc := range i.docsWithScope(normalizeTerm(alt)) {
+				result[doc.ID] = struct{}{}
+			}
+		}
+		return result, true
+	}
+
+	// Text fields: every term of the value (or of one of its synonyms) must match.
+	// The value is tokenized like the field was indexed, so stopwords are dropped
+	// except for keywords; a value made only of stopwords is skipped.
+	field, restricted := queryFields[n.Field]
+	applicable := false
+	for _, alt := range i.synonyms.expand(n.Value) {
+		tokens := i.queryTokens(n.Field, alt)
+		if len(tokens) == 0 {
+			continue
+		}
+		applicable = true
+		var matched docSet
+		for _, token := range tokens {
+			docs := docSet{}
+			for _, qt := range i.vocabulary.expand(token, opts) {
+				for id, p := range i.ranking.postings[qt.term] {
+					if !restricted || p.freqs[field] > 0 {
+						docs[id] = struct{}{}
+					}
+				}
+			}
+			if matched == nil {
+				matched = docs
+				continue
+			}
+			for id := range matched {
+				if _, ok := docs[id]; !ok {
+					delete(matched, id)
+				}
+			}
+		}
+		for id := range matched {
+			result[id] = struct{}{}
+		}
+	}
+	return result, applicable
+}
+
+// queryTokens splits a query value into terms the way the field was indexed:
+// keywords keep stopwords, free-text fields drop them
+func (i *Indexer) queryTokens(field, value string) []string {
+	if field == domain.FieldKeyword {
+		return i.Tokenizer.Terms(value)
+	}
+	return tokenTerms(i.Tokenizer.Tokenize(value))
+}
+
+// docsWithScope returns documents having the scope anywhere in their scope path
+func (i *Indexer) docsWithScope(scope string) []*domain.Document {
+	var docs []*domain.Document
+	for _, doc := range i.Documents {
+		for _, s := range doc.Scopes {
+			if strings.ToLower(s) == scope {
+				docs = append(docs, doc)
+				break
+			}
+		}
+	}
+	return docs
+}
+
+// textValues returns the values of the text clauses (any field, title, description, keyword, body)
+func textValues(terms []domain.TermNode) []string {
+	var values []string
+	for _, t := range terms {
+		if _, ok := queryFields[t.Field]; ok || t.Field == domain.FieldAny {
+			values = append(values, t.Value)
+		}
+	}
+	return values
+}
+
+// positiveTerms collects the term clauses that are not negated
+func positiveTerms(node domain.QueryNode) []domain.TermNode {
+	switch n := node.(type) {
+	case domain.TermNode:
+		return []domain.TermNode{n}
+	case domain.AndNode:
+		var terms []domain.TermNode
+		for _, child := range n.Children {
+			terms = append(terms, positiveTerms(child)...)
+		}
+		return terms
+	case domain.OrNode:
+		var terms []domain.TermNode
+		for _, child := range n.Children {
+			terms = append(terms, positiveTerms(child)...)
+		}
+		return terms
+	}
+	return nil
+}
+
+// filtersStatus reports whether the query selects documents by status (e.g. "status:deprecated")
+func filtersStatus(terms []domain.TermNode) bool {
+	for _, t := range terms {
+		if t.Field == domain.FieldStatus {
+			return true
+		}
+	}
+	return false
+}
+
+// targetsExplicitly reports whether the query selects documents by scope or ID itself
+func targetsExplicitly(terms []domain.TermNode) bool {
+	for _, t := range terms {
+		if t.Field == domain.FieldScope || t.Field == domain.FieldID {
+			return true
+		}
+	}
+	return false
+}
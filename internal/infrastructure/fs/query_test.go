@@ -0,0 +1,127 @@
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Search_Expr(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	docs := map[string]string{
+		"coding/go/go-errors.md":         "---\ntitle: Error Handling\nkeywords: [errors, wrap]\nstatus: adopted\n---\n",
+		"coding/go/go-legacy.md":         "---\ntitle: Legacy Error Handling\nkeywords: [errors, panic]\nstatus: adopted\n---\n",
+		"coding/go/go-testing.md":        "---\ntitle: Table Driven Tests\nkeywords: [testing]\nstatus: draft\n---\n",
+		"coding/typescript/ts-errors.md": "---\ntitle: Error Handling\nkeywords: [exceptions]\nstatus: adopted\n---\n",
+		"commits.md":                     "---\ntitle: Commit Messages\ndescription: Explain how errors were fixed\nkeywords: [vcs]\nstatus: adopted\n---\n",
+	}
+	for name, content := range docs {
+		path := filepath.Join(tmpDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := &logger.NoOpLogger{}
+	idx := New(NewLocalProvider(tmpDir, l), l)
+	idx.IncludeDrafts = true
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	term := func(field, value string) domain.TermNode {
+		return domain.TermNode{Field: field, Value: value}
+	}
+	and := func(children ...domain.QueryNode) domain.QueryNode {
+		return domain.AndNode{Children: children}
+	}
+
+	tests := []struct {
+		name  string
+		query domain.SearchQuery
+		want  []string
+	}{
+		{
+			name:  "it should require every term of an AND",
+			query: domain.SearchQuery{Expr: and(term("", "errors"), term("", "wrap")), Scopes: []string{"coding", "go"}},
+			want:  []string{"coding.go.go-errors"},
+		},
+		{
+			name:  "it should filter by scope anywhere in the scope path",
+			query: domain.SearchQuery{Expr: and(term("", "error"), term("scope", "go"))},
+			want:  []string{"coding.go.go-errors", "coding.go.go-legacy"},
+		},
+		{
+			name:  "it should exclude negated terms",
+			query: domain.SearchQuery{Expr: and(term("scope", "go"), term("", "errors"), domain.NotNode{Child: term("", "legacy")})},
+			want:  []string{"coding.go.go-errors"},
+		},
+		{
+			name:  "it should restrict text terms to the given field",
+			query: domain.SearchQuery{Expr: and(term("scope", "coding"), term("keyword", "errors"))},
+			want:  []string{"coding.go.go-errors", "coding.go.go-legacy"},
+		},
+		{
+			name:  "it should filter by status",
+			query: domain.SearchQuery{Expr: and(term("scope", "go"), term("status", "draft"))},
+			want:  []string{"coding.go.go-testing"},
+		},
+		{
+			name:  "it should match exact IDs",
+			query: domain.SearchQuery{Expr: term("id", "coding.typescript.ts-errors")},
+			want:  []string{"coding.typescript.ts-errors"},
+		},
+		{
+			name: "it should apply the scope subset rule without a scope filter",
+			query: domain.SearchQuery{
+				Expr:   domain.OrNode{Children: []domain.QueryNode{term("", "exceptions"), term("", "vcs")}},
+				Scopes: []string{"coding", "typescript"},
+			},
+			want: []string{"coding.typescript.ts-errors", "commits"},
+		},
+		{
+			name:  "it should ignore stopwords like the indexed text does",
+			query: domain.SearchQuery{Expr: and(term("", "the"), term("title", "table")), Scopes: []string{"coding", "go"}},
+			want:  []string{"coding.go.go-testing"},
+		},
+		{
+			name:  "it should ignore stopwords within a phrase",
+			query: domain.SearchQuery{Expr: term("description", "how the errors")},
+			want:  []string{"commits"},
+		},
+		{
+			name:  "it should match nothing for a query made only of stopwords",
+			query: domain.SearchQuery{Expr: term("", "the")},
+			want:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, r := range idx.Search(tt.query) {
+				got = append(got, r.ID)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("it should rank matches by their positive terms", func(t *testing.T) {
+		got := idx.Search(domain.SearchQuery{Expr: and(term("scope", "go"), term("", "panic"), domain.NotNode{Child: term("", "wrap")})})
+		if len(got) == 0 || got[0].ID != "coding.go.go-legacy" || got[0].Score <= 0 {
+			t.Errorf("expected go-legacy ranked first with a positive score, got %v", got)
+		}
+	})
+}
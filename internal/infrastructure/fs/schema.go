@@ -1,20 +1,43 @@
 package fs
 
-import "time"
+import (
+	"time"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
 
 // IndexSchema represents the structure of kex.json
 type IndexSchema struct {
 	GeneratedAt time.Time         `json:"generated_at"`
 	Documents   []*DocumentSchema `json:"documents"`
+	Synonyms    Synonyms          `json:"synonyms,omitempty"` // Alias table shipped with the source
 }
 
 // DocumentSchema represents a lightweight document entry in kex.json
 type DocumentSchema struct {
-	ID          string   `json:"id"`
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Keywords    []string `json:"keywords"`
-	Scopes      []string `json:"scopes"`
-	Status      string   `json:"status,omitempty"`
-	Path        string   `json:"path"` // Relative path to markdown file
+	ID           string                  `json:"id"`
+	Kind         string                  `json:"kind,omitempty"` // guideline (default) | prompt
+	Title        string                  `json:"title"`
+	Description  string                  `json:"description"`
+	Keywords     []string                `json:"keywords"`
+	Scopes       []string                `json:"scopes"`
+	AppliesTo    []string                `json:"appliesTo,omitempty"` // File patterns the document targets
+	Related      []string                `json:"related,omitempty"`
+	Supersedes   []string                `json:"supersedes,omitempty"`
+	Requires     []string                `json:"requires,omitempty"`
+	ReplacedBy   string                  `json:"replacedBy,omitempty"` // ID of the replacement of a retired document
+	Overrides    string                  `json:"overrides,omitempty"`  // ID of the referenced document this one overrides
+	OverrideMode string                  `json:"overrideMode,omitempty"`
+	Status       string                  `json:"status,omitempty"`
+	Severity     string                  `json:"severity,omitempty"`
+	Arguments    []domain.PromptArgument `json:"arguments,omitempty"` // Arguments of a prompt
+	Path         string                  `json:"path"`                // Relative path to markdown file
+	Size         int                     `json:"size,omitempty"`      // Body size in bytes (for token estimates)
+	Hash         string                  `json:"-"`                   // SHA-256 of a local file (keys the index cache)
+	Source       string                  `json:"-"`                   // Provider the document came from (set by CompositeProvider)
+	SourcePath   string                  `json:"-"`                   // Path within that provider, before CompositeProvider prefixes it
+
+	// Precomputed vector for semantic search (written by "kex generate --embeddings")
+	EmbeddingModel string    `json:"embeddingModel,omitempty"`
+	Embedding      []float32 `json:"embedding,omitempty"`
 }
@@ -0,0 +1,91 @@
+package fs
+
+import (
+	"strings"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// Scope modes (how a document's scope path is matched against the search context)
+const (
+	ScopeModeStrict  = "strict"  // Every scope of the document must be in the context (default)
+	ScopeModeInherit = "inherit" // Scopes imply their ancestors; documents nested below the context match
+)
+
+// scopeAllowed applies the scope rule of the configured mode.
+// Documents without scopes (root documents) are always allowed.
+func (i *Indexer) scopeAllowed(docScopes []string, validScopes map[string]struct{}) bool {
+	if i.ScopeMode == ScopeModeInherit {
+		return i.isNested(docScopes, validScopes)
+	}
+	return isSubset(docScopes, validScopes)
+}
+
+// isNested reports whether the document lives in or below the most specific scope the
+// context selects. The context is followed down the document's scope path; where it
+// stops, deeper documents match unless the context selects a sibling branch instead
+// (context go: coding/go/testing matches, but in context go, coding/typescript does not).
+func (i *Indexer) isNested(docScopes []string, validScopes map[string]struct{}) bool {
+	node := i.scopes
+	depth := 0
+	for _, scope := range docScopes {
+		key := strings.ToLower(scope)
+		if _, ok := validScopes[key]; !ok {
+			break
+		}
+		node = node.Children[key]
+		depth++
+	}
+
+	if depth == len(docScopes) {
+		return true
+	}
+	if depth == 0 || node == nil {
+		return false
+	}
+	for key := range node.Children {
+		if _, ok := validScopes[key]; ok {
+			return false
+		}
+	}
+	return true
+}
+
+// nestedDocuments returns the documents in or below every scope tree node the context selects
+func (i *Indexer) nestedDocuments(validScopes map[string]struct{}) []*domain.Document {
+	var docs []*domain.Document
+	var collect func(node *domain.ScopeNode, selected bool)
+	collect = func(node *domain.ScopeNode, selected bool) {
+		for key, child := range node.Children {
+			_, ok := validScopes[key]
+			inside := selected || ok
+			if inside {
+				docs = append(docs, child.Documents...)
+			}
+			collect(child, inside)
+		}
+	}
+	collect(i.scopes, false)
+	return docs
+}
+
+// addAncestorScopes makes a selected scope imply its ancestors (inherit mode only)
+func (i *Indexer) addAncestorScopes(validScopes map[string]struct{}) {
+	if i.ScopeMode != ScopeModeInherit {
+		return
+	}
+	var selected []string
+	for scope := range validScopes {
+		selected = append(selected, scope)
+	}
+	for _, scope := range selected {
+		for _, ancestor := range i.scopes.Ancestors(scope) {
+			validScopes[ancestor] = struct{}{}
+		}
+	}
+}
+
+// ScopeTree returns the scope hierarchy of the loaded documents
+func (i *Indexer) ScopeTree() *domain.ScopeNode {
+	return i.scopes
+}
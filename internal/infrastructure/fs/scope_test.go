@@ -0,0 +1,98 @@
+package fs
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Search_ScopeMode(t *testing.T) {
+	docs := []*DocumentSchema{
+		{ID: "root-rule", Title: "Root Rule", Keywords: []string{"rule"}},
+		{ID: "coding.general", Title: "General Coding", Keywords: []string{"rule"}, Scopes: []string{"coding"}},
+		{ID: "coding.go.errors", Title: "Go Errors", Keywords: []string{"rule"}, Scopes: []string{"coding", "go"}},
+		{ID: "coding.go.testing.table", Title: "Table Tests", Keywords: []string{"rule"}, Scopes: []string{"coding", "go", "testing"}},
+		{ID: "coding.typescript.types", Title: "Strict Types", Keywords: []string{"rule"}, Scopes: []string{"coding", "typescript"}},
+		{ID: "vcs.git.commits", Title: "Commit Messages", Keywords: []string{"rule"}, Scopes: []string{"vcs", "git"}},
+	}
+
+	load := func(mode string) *Indexer {
+		idx := New(&MockProvider{Documents: docs}, &logger.NoOpLogger{})
+		idx.ScopeMode = mode
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		return idx
+	}
+	ids := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		sort.Strings(out)
+		return out
+	}
+	goContext := []string{"coding", "go", "backend", "vcs"}
+
+	tests := []struct {
+		name     string
+		mode     string
+		query    domain.SearchQuery
+		expected []string
+	}{
+		{
+			name:     "strict mode should require every scope in the context",
+			mode:     ScopeModeStrict,
+			query:    domain.SearchQuery{Keywords: []string{"rule"}, Scopes: goContext},
+			expected: []string{"coding.general", "coding.go.errors", "root-rule"},
+		},
+		{
+			name:     "inherit mode should include documents nested below the context",
+			mode:     ScopeModeInherit,
+			query:    domain.SearchQuery{Keywords: []string{"rule"}, Scopes: goContext},
+			expected: []string{"coding.general", "coding.go.errors", "coding.go.testing.table", "root-rule", "vcs.git.commits"},
+		},
+		{
+			name:     "strict mode should only match a leaf scope with all its ancestors",
+			mode:     ScopeModeStrict,
+			query:    domain.SearchQuery{Keywords: []string{"testing"}, ExactScopeMatch: true},
+			expected: nil,
+		},
+		{
+			name:     "inherit mode should let a leaf scope imply its ancestors",
+			mode:     ScopeModeInherit,
+			query:    domain.SearchQuery{Keywords: []string{"testing"}, ExactScopeMatch: true},
+			expected: []string{"coding.general", "coding.go.errors", "coding.go.testing.table"},
+		},
+		{
+			name:     "strict mode should return the documents of the exact scopes when no keyword matches",
+			mode:     ScopeModeStrict,
+			query:    domain.SearchQuery{Keywords: []string{"nothing"}, Scopes: []string{"coding", "go"}, ExactScopeMatch: true},
+			expected: []string{"coding.general", "coding.go.errors"},
+		},
+		{
+			name:     "inherit mode should return nested documents when no keyword matches",
+			mode:     ScopeModeInherit,
+			query:    domain.SearchQuery{Keywords: []string{"nothing"}, Scopes: []string{"coding", "go"}, ExactScopeMatch: true},
+			expected: []string{"coding.general", "coding.go.errors", "coding.go.testing.table"},
+		},
+		{
+			name:     "inherit mode should return nested documents of the context when no keyword matches",
+			mode:     ScopeModeInherit,
+			query:    domain.SearchQuery{Keywords: []string{"nothing"}, Scopes: goContext},
+			expected: []string{"coding.general", "coding.go.errors", "coding.go.testing.table", "vcs.git.commits"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := load(tt.mode).Search(tt.query)
+			if got := ids(results); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package fs
+
+import (
+	"context"
+	"strings"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/embedding"
+)
+
+// Semantic search defaults
+const (
+	DefaultSemanticWeight = 0.5 // Share of the vector similarity in the blended score
+	DefaultMinSimilarity  = 0.3 // Similarity needed for a document without keyword matches
+)
+
+// embedBatchSize bounds the number of texts sent to the embedder at once
+const embedBatchSize = 64
+
+// SemanticOptions tunes how vector similarity is combined with keyword scores
+type SemanticOptions struct {
+	Weight        float64 // 0 = DefaultSemanticWeight
+	MinSimilarity float64 // 0 = DefaultMinSimilarity
+}
+
+func (o SemanticOptions) weight() float64 {
+	if o.Weight <= 0 {
+		return DefaultSemanticWeight
+	}
+	return min(o.Weight, 1)
+}
+
+func (o SemanticOptions) minSimilarity() float64 {
+	if o.MinSimilarity <= 0 {
+		return DefaultMinSimilarity
+	}
+	return o.MinSimilarity
+}
+
+// embeddingText is the text embedded for a document. Only metadata is used so that
+// vectors computed by "kex generate" match those computed at load time.
+func embeddingText(doc *domain.Document) string {
+	return strings.Join([]string{doc.Title, doc.Description, strings.Join(doc.Keywords, ", ")}, "\n")
+}
+
+// indexVectors embeds every document, reusing vectors shipped in the schema
+func (i *Indexer) indexVectors(schema *IndexSchema) {
+	i.VectorIndex = make(map[string][]float32)
+	docs := i.sortedDocuments()
+
+	if corpus, ok := i.Embedder.(embedding.CorpusEmbedder); ok {
+		texts := make([]string, len(docs))
+		for n, doc := range docs {
+			texts[n] = embeddingText(doc)
+		}
+		corpus.Fit(texts)
+	} else {
+		name := i.Embedder.Name()
+		for _, sd := range schema.Documents {
+			// Match the path too, so a dropped duplicate does not lend its vector
+			if doc, ok := i.Documents[sd.ID]; ok && doc.Path == sd.Path && sd.EmbeddingModel == name && len(sd.Embedding) > 0 {
+				i.VectorIndex[sd.ID] = sd.Embedding
+			}
+		}
+	}
+
+	var pending []*domain.Document
+	for _, doc := range docs {
+		if _, ok := i.VectorIndex[doc.ID]; !ok {
+			pending = append(pending, doc)
+		}
+	}
+
+	for start := 0; start < len(pending); start += embedBatchSize {
+		batch := pending[start:min(start+embedBatchSize, len(pending))]
+		texts := make([]string, len(batch))
+		for n, doc := range batch {
+			texts[n] = embeddingText(doc)
+		}
+		vectors, err := i.Embedder.Embed(context.Background(), texts)
+		if err != nil {
+			// Keyword search keeps working; documents without vectors just miss the semantic boost
+			i.Logger.Error("Failed to embed documents: %v", err)
+			return
+		}
+		for n, doc := range batch {
+			i.VectorIndex[doc.ID] = vectors[n]
+		}
+	}
+}
+
+// embedQuery returns the query vector, or nil when semantic search is off, fails or is cancelled.
+// It must be called without holding the lock: the embedder may be a slow remote endpoint.
+func (i *Indexer) embedQuery(ctx context.Context, values []string) []float32 {
+	if i.Embedder == nil || len(i.VectorIndex) == 0 || len(values) == 0 {
+		return nil
+	}
+	text := strings.Join(values, " ")
+	if queries, ok := i.Embedder.(embedding.QueryEmbedder); ok {
+		vector, err := queries.EmbedQuery(ctx, text)
+		if err != nil {
+			i.Logger.Error("Failed to embed query: %v", err)
+			return nil
+		}
+		return vector
+	}
+	vectors, err := i.Embedder.Embed(ctx, []string{text})
+	if err != nil {
+		i.Logger.Error("Failed to embed query: %v", err)
+		return nil
+	}
+	if len(vectors) == 0 {
+		i.Logger.Error("Failed to embed query: embedder returned no vectors")
+		return nil
+	}
+	return vectors[0]
+}
+
+// semanticCandidates returns documents similar enough to the query vector
+func (i *Indexer) semanticCandidates(queryVector []float32) []*domain.Document {
+	var docs []*domain.Document
+	for _, doc := range i.sortedDocuments() {
+		if embedding.Cosine(queryVector, i.VectorIndex[doc.ID]) >= i.Semantic.minSimilarity() {
+			docs = append(docs, doc)
+		}
+	}
+	return docs
+}
+
+// blendScores combines normalized keyword scores with vector similarity:
+// score = (1 - w) * keyword / maxKeyword + w * similarity
+func (i *Indexer) blendScores(results []domain.SearchResult, queryVector []float32) {
+	if queryVector == nil {
+		return
+	}
+
+	var maxScore float64
+	for _, r := range results {
+		maxScore = max(maxScore, r.Score)
+	}
+
+	w := i.Semantic.weight()
+	for n := range results {
+		var keyword float64
+		if maxScore > 0 {
+			keyword = results[n].Score / maxScore
+		}
+		similarity := max(embedding.Cosine(queryVector, i.VectorIndex[results[n].ID]), 0)
+		results[n].Score = (1-w)*keyword + w*similarity
+	}
+}
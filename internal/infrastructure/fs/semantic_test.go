@@ -0,0 +1,98 @@
+package fs
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+// conceptEmbedder maps texts onto two concepts: resilience and naming
+type conceptEmbedder struct {
+	embedded int
+}
+
+func (e *conceptEmbedder) Name() string { return "concepts" }
+
+func (e *conceptEmbedder) Embed(_ context.Context, texts []string) ([][]float32, error) {
+	e.embedded += len(texts)
+	vectors := make([][]float32, len(texts))
+	for n, text := range texts {
+		v := []float32{0, 0}
+		for _, w := range []string{"retry", "idempotent", "network"} {
+			if strings.Contains(strings.ToLower(text), w) {
+				v[0] = 1
+			}
+		}
+		if strings.Contains(strings.ToLower(text), "naming") {
+			v[1] = 1
+		}
+		vectors[n] = v
+	}
+	return vectors, nil
+}
+
+func TestIndexer_Search_Semantic(t *testing.T) {
+	newProvider := func() *MockProvider {
+		return &MockProvider{Documents: []*DocumentSchema{
+			{ID: "idempotent", Title: "Idempotent Network Calls", Keywords: []string{"http"}},
+			{ID: "naming", Title: "Naming", Keywords: []string{"naming"}},
+		}}
+	}
+	l := &logger.NoOpLogger{}
+
+	t.Run("it should find conceptually related documents without shared terms", func(t *testing.T) {
+		idx := New(newProvider(), l)
+		idx.Embedder = &conceptEmbedder{}
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+
+		got := idx.Search(domain.SearchQuery{Keywords: []string{"retry"}})
+		if len(got) != 1 || got[0].ID != "idempotent" {
+			t.Fatalf("expected only idempotent, got %v", got)
+		}
+		if got[0].Score <= 0 || got[0].Score > 1 {
+			t.Errorf("expected a blended score in (0, 1], got %f", got[0].Score)
+		}
+	})
+
+	t.Run("it should not match semantically without an embedder", func(t *testing.T) {
+		idx := New(newProvider(), l)
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if got := idx.Search(domain.SearchQuery{Keywords: []string{"retry"}}); len(got) != 0 {
+			t.Errorf("expected no results, got %v", got)
+		}
+	})
+
+	t.Run("it should export vectors and reuse them instead of re-embedding", func(t *testing.T) {
+		idx := New(newProvider(), l)
+		idx.Embedder = &conceptEmbedder{}
+		if err := idx.Load(); err != nil {
+			t.Fatal(err)
+		}
+		schema, err := idx.Export()
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, sd := range schema.Documents {
+			if sd.EmbeddingModel != "concepts" || len(sd.Embedding) != 2 {
+				t.Fatalf("expected exported embedding for %s, got %q %v", sd.ID, sd.EmbeddingModel, sd.Embedding)
+			}
+		}
+
+		embedder := &conceptEmbedder{}
+		consumer := New(&MockProvider{Documents: schema.Documents}, l)
+		consumer.Embedder = embedder
+		if err := consumer.Load(); err != nil {
+			t.Fatal(err)
+		}
+		if embedder.embedded != 0 {
+			t.Errorf("expected precomputed vectors to be reused, embedded %d texts", embedder.embedded)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package fs
+
+import "github.com/mew-ton/kex/internal/domain"
+
+// DefaultSeverityWeights scale the final score, so mandatory rules outrank advice of similar relevance
+var DefaultSeverityWeights = map[domain.Severity]float64{
+	domain.SeverityMust:   1.5,
+	domain.SeverityShould: 1.0,
+	domain.SeverityMay:    0.75,
+}
+
+// applySeverity multiplies each score by the weight of the document's severity
+func (i *Indexer) applySeverity(results []domain.SearchResult) {
+	weights := i.SeverityWeights
+	if weights == nil {
+		weights = DefaultSeverityWeights
+	}
+	for n := range results {
+		if w, ok := weights[results[n].EffectiveSeverity()]; ok {
+			results[n].Score *= w
+		}
+	}
+}
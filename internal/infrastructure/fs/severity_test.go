@@ -0,0 +1,46 @@
+package fs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Search_Severity(t *testing.T) {
+	idx := New(&MockProvider{Documents: []*DocumentSchema{
+		{ID: "early-returns", Title: "Prefer Early Returns", Keywords: []string{"style"}, Severity: "may"},
+		{ID: "naming", Title: "Naming", Keywords: []string{"style"}},
+		{ID: "secrets", Title: "Never Log Secrets", Keywords: []string{"style"}, Severity: "must"},
+	}}, &logger.NoOpLogger{})
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	t.Run("it should rank equally relevant documents by severity", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Keywords: []string{"style"}})
+		if got, want := ids(results), []string{"secrets", "naming", "early-returns"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("it should filter by severity, defaulting to should", func(t *testing.T) {
+		must := idx.Search(domain.SearchQuery{Expr: domain.TermNode{Field: domain.FieldSeverity, Value: "must"}})
+		if got, want := ids(must), []string{"secrets"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		should := idx.Search(domain.SearchQuery{Expr: domain.TermNode{Field: domain.FieldSeverity, Value: "should"}})
+		if got, want := ids(should), []string{"naming"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+}
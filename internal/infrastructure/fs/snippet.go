@@ -0,0 +1,197 @@
+package fs
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// Snippet sizing (in bytes of source text)
+const (
+	snippetLength  = 160 // Length of the excerpt
+	snippetContext = 40  // Text kept before the first highlighted match
+)
+
+// highlightMark surrounds matched terms in snippets (markdown bold)
+const highlightMark = "**"
+
+// snippetFields are tried in order. The body comes first because the title and
+// description are shown with every result anyway.
+var snippetFields = []Field{FieldBody, FieldDescription, FieldTitle}
+
+// snippet returns the field that best shows why the document matched and an
+// excerpt of it with the matched terms highlighted. Body excerpts are taken from
+// the section with the most matches, whose slug is returned as well.
+func (i *Indexer) snippet(doc *domain.Document, terms []queryTerm) (Field, string, string) {
+	for _, field := range snippetFields {
+		spans := i.ranking.spans(doc.ID, field, terms)
+		if len(spans) == 0 {
+			continue
+		}
+		if field == FieldBody {
+			body := i.bodies[doc.ID]
+			if section, ok := bestSection(body, spans); ok {
+				return field, excerpt(section.Content(body), spansWithin(spans, section)), section.Slug
+			}
+		}
+		return field, excerpt(i.fieldText(doc, field), spans), ""
+	}
+
+	if s := i.keywordSnippet(doc, terms); s != "" {
+		return FieldKeywords, s, ""
+	}
+	return "", "", ""
+}
+
+// bestSection returns the innermost section holding the most matches (the first one on ties)
+func bestSection(body string, spans []span) (domain.Section, bool) {
+	sections := domain.ParseSections(body)
+	counts := make(map[int]int)
+	for _, s := range spans {
+		if section, ok := domain.SectionAt(sections, s.start); ok {
+			counts[section.Start]++
+		}
+	}
+
+	var best domain.Section
+	bestCount := 0
+	for _, section := range sections {
+		if n := counts[section.Start]; n > bestCount {
+			best, bestCount = section, n
+		}
+	}
+	return best, bestCount > 0
+}
+
+// spansWithin keeps the spans inside the section, relative to its start
+func spansWithin(spans []span, section domain.Section) []span {
+	var within []span
+	for _, s := range spans {
+		if s.start >= section.Start && s.end <= section.End {
+			within = append(within, span{start: s.start - section.Start, end: s.end - section.Start})
+		}
+	}
+	return within
+}
+
+// fieldText returns the text of a field; the read lock must be held for the body
+func (i *Indexer) fieldText(doc *domain.Document, field Field) string {
+	switch field {
+	case FieldTitle:
+		return doc.Title
+	case FieldDescription:
+		return doc.Description
+	case FieldBody:
+		return i.bodies[doc.ID]
+	}
+	return ""
+}
+
+// keywordSnippet lists the document keywords, highlighting the matched ones
+func (i *Indexer) keywordSnippet(doc *domain.Document, terms []queryTerm) string {
+	matched := make(map[string]struct{}, len(terms))
+	for _, qt := range terms {
+		matched[qt.term] = struct{}{}
+	}
+
+	var parts []string
+	hit := false
+	for _, keyword := range doc.Keywords {
+		part := keyword
+		for _, term := range i.Tokenizer.Terms(keyword) {
+			if _, ok := matched[term]; ok {
+				part = highlightMark + keyword + highlightMark
+				hit = true
+				break
+			}
+		}
+		parts = append(parts, part)
+	}
+	if !hit {
+		return ""
+	}
+	return strings.Join(parts, ", ")
+}
+
+// excerpt cuts the window of text containing the most matches and highlights them.
+// Whitespace (including newlines) is collapsed so the snippet fits on one line.
+func excerpt(text string, spans []span) string {
+	spans = mergeSpans(spans)
+
+	// Pick the window starting at the match followed by the most matches
+	best, bestCount := 0, 0
+	for a := range spans {
+		count := 0
+		for b := a; b < len(spans) && spans[b].end <= spans[a].start+snippetLength-snippetContext; b++ {
+			count++
+		}
+		if count > bestCount {
+			best, bestCount = a, count
+		}
+	}
+
+	first := spans[best].start
+	start := max(first-snippetContext, 0)
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	if start > 0 {
+		// Begin at a word boundary
+		if ws := strings.IndexFunc(text[start:first], unicode.IsSpace); ws >= 0 {
+			start += ws + 1
+		}
+	}
+
+	end := min(start+snippetLength, len(text))
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	if end < len(text) {
+		// End at a word boundary, after the last match that fits
+		lastEnd := first
+		for _, s := range spans[best:] {
+			if s.end <= end {
+				lastEnd = s.end
+			}
+		}
+		if ws := strings.LastIndexFunc(text[lastEnd:end], unicode.IsSpace); ws >= 0 {
+			end = lastEnd + ws
+		}
+	}
+
+	var b strings.Builder
+	pos := start
+	for _, s := range spans {
+		if s.start < start || s.end > end {
+			continue
+		}
+		b.WriteString(text[pos:s.start])
+		b.WriteString(highlightMark + text[s.start:s.end] + highlightMark)
+		pos = s.end
+	}
+	b.WriteString(text[pos:end])
+
+	snippet := strings.Join(strings.Fields(b.String()), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(text) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// mergeSpans joins overlapping spans (e.g. CJK bigrams) of sorted spans
+func mergeSpans(spans []span) []span {
+	var merged []span
+	for _, s := range spans {
+		if n := len(merged); n > 0 && s.start <= merged[n-1].end {
+			merged[n-1].end = max(merged[n-1].end, s.end)
+			continue
+		}
+		merged = append(merged, s)
+	}
+	return merged
+}
@@ -0,0 +1,154 @@
+package fs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestExcerpt(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		spans []span
+		want  string
+	}{
+		{
+			name:  "it should highlight matches in short text",
+			text:  "Wrap errors with context",
+			spans: []span{{5, 11}},
+			want:  "Wrap **errors** with context",
+		},
+		{
+			name:  "it should collapse whitespace and newlines",
+			text:  "## Errors\n\nAlways wrap\n  errors.",
+			spans: []span{{3, 9}, {25, 31}},
+			want:  "## **Errors** Always wrap **errors**.",
+		},
+		{
+			name:  "it should merge overlapping spans",
+			text:  "日本語の文書",
+			spans: []span{{0, 6}, {3, 9}},
+			want:  "**日本語**の文書",
+		},
+		{
+			name:  "it should cut long text around the matches at word boundaries",
+			text:  strings.Repeat("lorem ipsum ", 20) + "wrap errors here " + strings.Repeat("dolor sit ", 20),
+			spans: []span{{245, 251}},
+			want:  "…ipsum lorem ipsum lorem ipsum wrap **errors** here dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit dolor sit…",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := excerpt(tt.text, tt.spans); got != tt.want {
+				t.Errorf("excerpt() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexer_Search_Snippets(t *testing.T) {
+	body := "# Guide\n\nWhen a call fails, always wrap the error with context before returning it.\n"
+	provider := &MockProvider{
+		Documents: []*DocumentSchema{
+			{ID: "errors", Title: "Error Handling", Description: "How to report failures", Keywords: []string{"wrap", "context"}, Path: "errors.md"},
+		},
+		Content: map[string]string{"errors.md": body},
+	}
+
+	tests := []struct {
+		name      string
+		fullText  bool
+		keywords  []string
+		wantField string
+		want      string
+	}{
+		{
+			name:      "it should take the snippet from the body when indexed",
+			fullText:  true,
+			keywords:  []string{"fails"},
+			wantField: "body",
+			want:      "# Guide When a call **fails**, always wrap the error with context before returning it.",
+		},
+		{
+			name:      "it should fall back to the description",
+			keywords:  []string{"failures"},
+			wantField: "description",
+			want:      "How to report **failures**",
+		},
+		{
+			name:      "it should list keywords when only a keyword matched",
+			keywords:  []string{"wrap"},
+			wantField: "keywords",
+			want:      "**wrap**, context",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := &logger.NoOpLogger{}
+			idx := New(provider, l)
+			idx.FullText = tt.fullText
+			if err := idx.Load(); err != nil {
+				t.Fatal(err)
+			}
+
+			got := idx.Search(domain.SearchQuery{Keywords: tt.keywords})
+			if len(got) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(got))
+			}
+			if got[0].MatchedField != tt.wantField || got[0].Snippet != tt.want {
+				t.Errorf("snippet = (%q, %q), want (%q, %q)", got[0].MatchedField, got[0].Snippet, tt.wantField, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexer_Search_BestSection(t *testing.T) {
+	body := "## Summary\n\nWrap errors.\n\n## Wrapping\n\nWrap errors with `%w` so callers can unwrap errors.\n\n### Sentinel Errors\n\nCompare sentinel values with errors.Is.\n"
+	provider := &MockProvider{
+		Documents: []*DocumentSchema{{ID: "errors", Title: "Error Handling", Path: "errors.md"}},
+		Content:   map[string]string{"errors.md": body},
+	}
+	l := &logger.NoOpLogger{}
+	idx := New(provider, l)
+	idx.FullText = true
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		keywords    []string
+		wantSection string
+		wantSnippet string
+	}{
+		{
+			name:        "it should point to the section with the most matches",
+			keywords:    []string{"unwrap", "wrap"},
+			wantSection: "wrapping",
+			wantSnippet: "## **Wrapping** **Wrap** errors with `%w` so callers can **unwrap** errors. ### Sentinel Errors Compare sentinel values with errors.Is.",
+		},
+		{
+			name:        "it should point to a nested section",
+			keywords:    []string{"sentinel"},
+			wantSection: "sentinel-errors",
+			wantSnippet: "### **Sentinel** Errors Compare **sentinel** values with errors.Is.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := idx.Search(domain.SearchQuery{Keywords: tt.keywords})
+			if len(got) != 1 {
+				t.Fatalf("expected 1 result, got %d", len(got))
+			}
+			if got[0].Section != tt.wantSection || got[0].Snippet != tt.wantSnippet {
+				t.Errorf("section = (%q, %q), want (%q, %q)", got[0].Section, got[0].Snippet, tt.wantSection, tt.wantSnippet)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package fs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func TestIndexer_Search_Retired(t *testing.T) {
+	idx := New(&MockProvider{Documents: []*DocumentSchema{
+		{ID: "logging", Title: "Structured Logging", Keywords: []string{"logging"}},
+		{ID: "old-logging", Title: "Printf Logging", Keywords: []string{"logging"}, Status: "deprecated", ReplacedBy: "logging"},
+		{ID: "ancient-logging", Title: "Syslog Logging", Keywords: []string{"logging"}, Status: "archived"},
+		{ID: "prompts.logging", Title: "Review Logging", Keywords: []string{"logging"}, Kind: "prompt"},
+	}}, &logger.NoOpLogger{})
+	if err := idx.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ids := func(results []domain.SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.ID)
+		}
+		return out
+	}
+
+	t.Run("it should exclude retired documents from search", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Keywords: []string{"logging"}})
+		if got, want := ids(results), []string{"logging"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+		expr := idx.Search(domain.SearchQuery{Expr: domain.TermNode{Field: domain.FieldAny, Value: "logging"}})
+		if got, want := ids(expr), []string{"logging"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("it should return retired documents when filtering by status", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Expr: domain.TermNode{Field: domain.FieldStatus, Value: "deprecated"}})
+		if got, want := ids(results), []string{"old-logging"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("it should never return prompts", func(t *testing.T) {
+		results := idx.Search(domain.SearchQuery{Expr: domain.TermNode{Field: domain.FieldStatus, Value: "adopted"}})
+		if got, want := ids(results), []string{"logging"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("it should keep retired documents readable", func(t *testing.T) {
+		doc, ok := idx.GetByID("old-logging")
+		if !ok || doc.ReplacedBy != "logging" {
+			t.Errorf("expected old-logging replaced by logging, got %v", doc)
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package fs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SynonymsFileName is the optional alias table at the root of a local source
+const SynonymsFileName = "_synonyms.yaml"
+
+// Synonyms maps a canonical term to its aliases (e.g. "typescript": ["ts"]).
+// All members of an entry are treated as equivalent in both directions.
+type Synonyms map[string][]string
+
+// loadSynonymsFile reads a synonyms file; a missing file yields no synonyms
+func loadSynonymsFile(path string) (Synonyms, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var synonyms Synonyms
+	if err := yaml.Unmarshal(data, &synonyms); err != nil {
+		return nil, fmt.Errorf("%s: failed to parse synonyms: %w", filepath.Base(path), err)
+	}
+	return synonyms, nil
+}
+
+// mergeSynonyms combines alias tables, uniting the aliases of shared canonical terms
+func mergeSynonyms(tables ...Synonyms) Synonyms {
+	merged := Synonyms{}
+	for _, table := range tables {
+		for canonical, aliases := range table {
+			merged[canonical] = append(merged[canonical], aliases...)
+		}
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// synonymTable resolves a term to every term equivalent to it
+type synonymTable struct {
+	groups map[string][]string // Normalized term -> All equivalent terms (including itself)
+}
+
+func newSynonymTable(synonyms Synonyms) *synonymTable {
+	t := &synonymTable{groups: make(map[string][]string)}
+
+	// Collect each entry as a group, merging groups that share a term
+	// (e.g. "go: [golang]" and "golang: [go-lang]" form one group)
+	members := make(map[string]map[string]struct{})
+	for canonical, aliases := range synonyms {
+		group := map[string]struct{}{}
+		for _, term := range append([]string{canonical}, aliases...) {
+			if k := normalizeTerm(term); k != "" {
+				group[k] = struct{}{}
+			}
+		}
+		var linked []map[string]struct{}
+		for term := range group {
+			if existing, ok := members[term]; ok {
+				linked = append(linked, existing)
+			}
+		}
+		for _, existing := range linked {
+			for other := range existing {
+				group[other] = struct{}{}
+			}
+		}
+		for term := range group {
+			members[term] = group
+		}
+	}
+
+	for term, group := range members {
+		list := make([]string, 0, len(group))
+		for member := range group {
+			list = append(list, member)
+		}
+		sort.Strings(list)
+		t.groups[term] = list
+	}
+	return t
+}
+
+// expand returns the term followed by its equivalents
+func (t *synonymTable) expand(term string) []string {
+	k := normalizeTerm(term)
+	expanded := []string{term}
+	for _, member := range t.groups[k] {
+		if member != k {
+			expanded = append(expanded, member)
+		}
+	}
+	return expanded
+}
+
+// expandAll expands every term, keeping the original order and dropping duplicates
+func (t *synonymTable) expandAll(terms []string) []string {
+	seen := make(map[string]struct{})
+	var expanded []string
+	for _, term := range terms {
+		for _, e := range t.expand(term) {
+			k := normalizeTerm(e)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			expanded = append(expanded, e)
+		}
+	}
+	return expanded
+}
@@ -0,0 +1,174 @@
+package fs
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Token is an index term and the byte range of the source text it was produced from
+type Token struct {
+	Term  string
+	Start int
+	End   int
+}
+
+// Tokenizer converts text into index terms.
+// The same Tokenizer must be used for indexing and for queries so that terms agree.
+type Tokenizer interface {
+	// Tokenize splits free text (titles, descriptions, bodies) into terms, dropping stopwords
+	Tokenize(text string) []Token
+	// Terms splits a curated phrase (keywords, query terms) into terms, keeping stopwords
+	Terms(phrase string) []string
+}
+
+// Supported tokenizer languages
+const (
+	LanguageEnglish = "english" // Porter stemming and English stopwords
+	LanguageNone    = "none"    // Lowercasing only
+)
+
+// DefaultStopwords are common English words that carry no meaning for guideline search.
+// "use" is included because nearly every guideline title starts with it.
+var DefaultStopwords = []string{
+	"a", "an", "and", "are", "as", "at", "be", "by", "do", "for", "from", "how",
+	"if", "in", "into", "is", "it", "its", "of", "on", "or", "that", "the",
+	"their", "them", "then", "there", "these", "this", "to", "was", "were",
+	"what", "when", "which", "with", "you", "your",
+	"use", "used", "uses", "using",
+}
+
+// StandardTokenizer splits on punctuation and whitespace, stems English words
+// and splits CJK text (which has no spaces) into overlapping bigrams.
+type StandardTokenizer struct {
+	Stemming  bool
+	Stopwords map[string]struct{}
+}
+
+// NewTokenizer creates a StandardTokenizer for the language.
+// Extra stopwords are added to the language defaults.
+func NewTokenizer(language string, extraStopwords []string) *StandardTokenizer {
+	t := &StandardTokenizer{Stopwords: make(map[string]struct{})}
+
+	if language != LanguageNone {
+		t.Stemming = true
+		for _, w := range DefaultStopwords {
+			t.Stopwords[w] = struct{}{}
+		}
+	}
+	for _, w := range extraStopwords {
+		t.Stopwords[strings.ToLower(w)] = struct{}{}
+	}
+	return t
+}
+
+func (t *StandardTokenizer) Tokenize(text string) []Token {
+	return t.tokenize(text, true)
+}
+
+func (t *StandardTokenizer) Terms(phrase string) []string {
+	return tokenTerms(t.tokenize(phrase, false))
+}
+
+func (t *StandardTokenizer) tokenize(text string, dropStopwords bool) []Token {
+	var tokens []Token
+	for _, seg := range segment(text) {
+		word := text[seg.start:seg.end]
+
+		if seg.cjk {
+			tokens = append(tokens, bigrams(word, seg.start)...)
+			continue
+		}
+
+		term := strings.ToLower(word)
+		if dropStopwords {
+			if _, stop := t.Stopwords[term]; stop {
+				continue
+			}
+		}
+		if t.Stemming {
+			term = porterStem(term)
+		}
+		tokens = append(tokens, Token{Term: term, Start: seg.start, End: seg.end})
+	}
+	return tokens
+}
+
+// textSegment is a run of word characters; CJK runs are kept separate
+type textSegment struct {
+	start, end int
+	cjk        bool
+}
+
+// segment splits text into runs of letters/digits, separating CJK runs from other scripts
+// (e.g. "Go言語のテスト" -> "Go", "言語のテスト")
+func segment(text string) []textSegment {
+	var segs []textSegment
+	start := -1
+	var startCJK bool
+
+	flush := func(end int) {
+		if start >= 0 {
+			segs = append(segs, textSegment{start: start, end: end, cjk: startCJK})
+			start = -1
+		}
+	}
+
+	for pos, r := range text {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush(pos)
+			continue
+		}
+		cjk := isCJK(r)
+		if start >= 0 && cjk != startCJK {
+			flush(pos)
+		}
+		if start < 0 {
+			start = pos
+			startCJK = cjk
+		}
+	}
+	flush(len(text))
+	return segs
+}
+
+// bigrams splits a CJK run into overlapping two-character terms (single characters stay as-is)
+func bigrams(run string, offset int) []Token {
+	type char struct {
+		r   rune
+		pos int
+	}
+	var chars []char
+	for pos, r := range run {
+		chars = append(chars, char{r: r, pos: offset + pos})
+	}
+
+	end := func(c char) int { return c.pos + len(string(c.r)) }
+
+	if len(chars) == 1 {
+		return []Token{{Term: string(chars[0].r), Start: chars[0].pos, End: end(chars[0])}}
+	}
+
+	tokens := make([]Token, 0, len(chars)-1)
+	for k := 0; k+1 < len(chars); k++ {
+		tokens = append(tokens, Token{
+			Term:  string([]rune{chars[k].r, chars[k+1].r}),
+			Start: chars[k].pos,
+			End:   end(chars[k+1]),
+		})
+	}
+	return tokens
+}
+
+func isCJK(r rune) bool {
+	// U+30FC (prolonged sound mark, e.g. "サーバー") belongs to the Common script
+	return r == '\u30fc' || unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
+
+// tokenTerms extracts the terms of tokens
+func tokenTerms(tokens []Token) []string {
+	terms := make([]string, 0, len(tokens))
+	for _, tok := range tokens {
+		terms = append(terms, tok.Term)
+	}
+	return terms
+}
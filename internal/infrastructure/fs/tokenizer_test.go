@@ -0,0 +1,116 @@
+package fs
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStandardTokenizer_Tokenize(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer *StandardTokenizer
+		text      string
+		want      []string
+	}{
+		{
+			name:      "it should strip punctuation",
+			tokenizer: NewTokenizer(LanguageNone, nil),
+			text:      "Prefer TypeScript, not JavaScript.",
+			want:      []string{"prefer", "typescript", "not", "javascript"},
+		},
+		{
+			name:      "it should split markdown and code identifiers",
+			tokenizer: NewTokenizer(LanguageNone, nil),
+			text:      "## Guidance\n`errgroup.Group` for fan-out",
+			want:      []string{"guidance", "errgroup", "group", "for", "fan", "out"},
+		},
+		{
+			name:      "it should drop stopwords and stem English words",
+			tokenizer: NewTokenizer(LanguageEnglish, nil),
+			text:      "Use the errors package when avoiding panics",
+			want:      []string{"error", "packag", "avoid", "panic"},
+		},
+		{
+			name:      "it should drop extra stopwords",
+			tokenizer: NewTokenizer(LanguageNone, []string{"Prefer"}),
+			text:      "Prefer composition",
+			want:      []string{"composition"},
+		},
+		{
+			name:      "it should split CJK text into bigrams",
+			tokenizer: NewTokenizer(LanguageEnglish, nil),
+			text:      "Go言語のテスト",
+			want:      []string{"go", "言語", "語の", "のテ", "テス", "スト"},
+		},
+		{
+			name:      "it should keep a single CJK character",
+			tokenizer: NewTokenizer(LanguageEnglish, nil),
+			text:      "型 safety",
+			want:      []string{"型", "safeti"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tokenTerms(tt.tokenizer.Tokenize(tt.text)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStandardTokenizer_Offsets(t *testing.T) {
+	text := "Wrap 日本語"
+	tokens := NewTokenizer(LanguageEnglish, nil).Tokenize(text)
+
+	want := []string{"Wrap", "日本", "本語"}
+	if len(tokens) != len(want) {
+		t.Fatalf("got %d tokens, want %d", len(tokens), len(want))
+	}
+	for k, tok := range tokens {
+		if got := text[tok.Start:tok.End]; got != want[k] {
+			t.Errorf("token %d covers %q, want %q", k, got, want[k])
+		}
+	}
+}
+
+func TestStandardTokenizer_Terms(t *testing.T) {
+	tokenizer := NewTokenizer(LanguageEnglish, nil)
+
+	t.Run("it should keep stopwords in curated phrases", func(t *testing.T) {
+		got := tokenizer.Terms("use")
+		if !reflect.DeepEqual(got, []string{"us"}) {
+			t.Errorf("Terms() = %v", got)
+		}
+	})
+
+	t.Run("it should stem inflections to the same term", func(t *testing.T) {
+		a, b := tokenizer.Terms("avoid"), tokenizer.Terms("avoiding")
+		if !reflect.DeepEqual(a, b) {
+			t.Errorf("Terms(avoid) = %v, Terms(avoiding) = %v", a, b)
+		}
+	})
+}
+
+func TestPorterStem(t *testing.T) {
+	tests := map[string]string{
+		"caresses":        "caress",
+		"ponies":          "poni",
+		"agreed":          "agre",
+		"hopping":         "hop",
+		"filing":          "file",
+		"happy":           "happi",
+		"relational":      "relat",
+		"conditional":     "condit",
+		"generalizations": "gener",
+		"testing":         "test",
+		"tests":           "test",
+		"go":              "go",
+		"TypeScript":      "TypeScript", // Not lowercase ASCII: left as-is
+	}
+	for word, want := range tests {
+		if got := porterStem(word); got != want {
+			t.Errorf("porterStem(%q) = %q, want %q", word, got, want)
+		}
+	}
+}
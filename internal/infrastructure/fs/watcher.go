@@ -0,0 +1,104 @@
+package fs
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// DefaultWatchInterval is how often watched directories are scanned for changes
+	DefaultWatchInterval = time.Second
+	// DefaultWatchDebounce is how long changes must settle before OnChange is called
+	DefaultWatchDebounce = 500 * time.Millisecond
+)
+
+// Watcher polls local source directories and reports changes to markdown files.
+// Polling keeps kex free of platform-specific notification APIs and works on
+// network and container mounts where those are unreliable.
+type Watcher struct {
+	Roots    []string
+	Interval time.Duration // 0 = DefaultWatchInterval
+	Debounce time.Duration // 0 = DefaultWatchDebounce
+	OnChange func()        // Called once a burst of changes has settled
+}
+
+// fileStamp identifies a version of a file without reading it
+type fileStamp struct {
+	modTime int64
+	size    int64
+}
+
+// Run watches until the context is cancelled
+func (w *Watcher) Run(ctx context.Context) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+	debounce := w.Debounce
+	if debounce <= 0 {
+		debounce = DefaultWatchDebounce
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	last := w.snapshot()
+	var pendingSince time.Time // Zero = no unreported changes
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			current := w.snapshot()
+			if !sameSnapshot(last, current) {
+				// Restart the debounce window on every change (editors write in bursts)
+				last, pendingSince = current, now
+				continue
+			}
+			if !pendingSince.IsZero() && now.Sub(pendingSince) >= debounce {
+				pendingSince = time.Time{}
+				w.OnChange()
+			}
+		}
+	}
+}
+
+// snapshot records the markdown files under all roots. Unreadable paths are skipped.
+func (w *Watcher) snapshot() map[string]fileStamp {
+	files := make(map[string]fileStamp)
+	for _, root := range w.Roots {
+		filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !isWatchedFile(path) {
+				return nil
+			}
+			info, err := os.Stat(path)
+			if err != nil {
+				return nil
+			}
+			files[path] = fileStamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+			return nil
+		})
+	}
+	return files
+}
+
+// isWatchedFile matches the files a LocalProvider loads (documents and _synonyms.yaml)
+func isWatchedFile(path string) bool {
+	return filepath.Ext(path) == ".md" || filepath.Base(path) == SynonymsFileName
+}
+
+func sameSnapshot(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		if b[path] != stamp {
+			return false
+		}
+	}
+	return true
+}
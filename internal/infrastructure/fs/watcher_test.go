@@ -0,0 +1,63 @@
+package fs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatcher_Run(t *testing.T) {
+	root := t.TempDir()
+	changes := make(chan struct{}, 10)
+	w := &Watcher{
+		Roots:    []string{root},
+		Interval: 10 * time.Millisecond,
+		Debounce: 30 * time.Millisecond,
+		OnChange: func() { changes <- struct{}{} },
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go w.Run(ctx)
+	time.Sleep(30 * time.Millisecond) // Let the initial snapshot settle
+
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectChange := func(want bool) {
+		t.Helper()
+		select {
+		case <-changes:
+			if !want {
+				t.Error("unexpected change notification")
+			}
+		case <-time.After(300 * time.Millisecond):
+			if want {
+				t.Error("expected a change notification")
+			}
+		}
+	}
+
+	t.Run("it should report a burst of markdown changes once", func(t *testing.T) {
+		write("a.md", "one")
+		write("b.md", "two")
+		expectChange(true)
+		expectChange(false)
+	})
+
+	t.Run("it should ignore files that are not documents", func(t *testing.T) {
+		write("notes.txt", "ignored")
+		expectChange(false)
+	})
+
+	t.Run("it should report deleted documents", func(t *testing.T) {
+		if err := os.Remove(filepath.Join(root, "a.md")); err != nil {
+			t.Fatal(err)
+		}
+		expectChange(true)
+	})
+}
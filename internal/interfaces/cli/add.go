@@ -3,8 +3,9 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strings"
 
-	// Added missing import for strings
+	"github.com/mew-ton/kex/internal/domain"
 	"github.com/mew-ton/kex/internal/infrastructure/config"
 	"github.com/mew-ton/kex/internal/infrastructure/fs"
 	"github.com/mew-ton/kex/internal/infrastructure/logger"
@@ -16,7 +17,13 @@ var AddCommand = &cli.Command{
 	Name:      "add",
 	Usage:     "Add a document source reference",
 	ArgsUsage: "[path_or_url]",
-	Action:    runAdd,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "namespace",
+			Usage: "Prefix the reference's document IDs (e.g. 'org' serves 'org:coding.go.naming')",
+		},
+	},
+	Action: runAdd,
 }
 
 func runAdd(c *cli.Context) error {
@@ -53,6 +60,11 @@ func runAdd(c *cli.Context) error {
 		// config.Load handles IsNotExist by returning default.
 	}
 
+	namespace := c.String("namespace")
+	if strings.Contains(namespace, domain.NamespaceSeparator) {
+		return cli.Exit(fmt.Sprintf("Error: namespace must not contain '%s'", domain.NamespaceSeparator), 1)
+	}
+
 	// 3. Append
 	// Check for duplicates
 	for _, ref := range cfg.References {
@@ -63,6 +75,12 @@ func runAdd(c *cli.Context) error {
 	}
 
 	cfg.References = append(cfg.References, arg)
+	if namespace != "" {
+		if cfg.Namespaces == nil {
+			cfg.Namespaces = make(map[string]string)
+		}
+		cfg.Namespaces[arg] = namespace
+	}
 
 	// 4. Save
 	if err := config.Save(cwd, cfg); err != nil {
@@ -23,6 +23,10 @@ var CheckCommand = &cli.Command{
 			Name:  "json",
 			Usage: "Output results in JSON format",
 		},
+		&cli.BoolFlag{
+			Name:  "scopes",
+			Usage: "Print the scope tree",
+		},
 	},
 	Action: runCheck,
 }
@@ -64,6 +68,13 @@ func runCheck(c *cli.Context) error {
 	// Initialize Validator with default rules
 	rules := []validator.ValidationRule{
 		&validator.TitleRequiredRule{},
+		&validator.AppliesToPatternRule{},
+		&validator.SeverityRule{},
+		&validator.StatusRule{},
+		&validator.OverrideRule{},
+		&validator.PromptRule{Repo: repo},
+		&validator.RelationsRule{Repo: repo},
+		&validator.DeprecatedLinksRule{Repo: repo},
 	}
 	v := validator.New(rules)
 	report := v.Validate(repo)
@@ -72,6 +83,10 @@ func runCheck(c *cli.Context) error {
 		printJSONReport(report)
 	} else {
 		printHumanReport(report, report.Stats.Total)
+		if c.Bool("scopes") {
+			pterm.DefaultSection.Println("Scopes")
+			printScopeTree(repo.ScopeTree(), false)
+		}
 	}
 
 	if !report.Valid {
@@ -132,6 +147,15 @@ func loadRepository(projectRoot string, cfg config.Config, showSpinner bool) (*f
 	composite := fs.NewCompositeProvider(providers)
 	repo := fs.New(composite, l)
 	repo.IncludeDrafts = true
+	repo.StrictIDs = true
+	waivers, err := resolveWaivers(cfg.Waivers)
+	if err != nil {
+		if spinner != nil {
+			spinner.Fail("Invalid waivers")
+		}
+		return nil, err
+	}
+	repo.Waivers = waivers
 	if err := repo.Load(); err != nil {
 		if spinner != nil {
 			spinner.Fail("Failed to load documents")
@@ -212,6 +236,7 @@ func printHumanReport(report validator.ValidationReport, totalDocs int) {
 		{"Total Documents", fmt.Sprintf("%d", totalDocs)},
 		{"Adopted", fmt.Sprintf("%d", report.Stats.Adopted)},
 		{"Draft", fmt.Sprintf("%d", report.Stats.Draft)},
+		{"Deprecated", fmt.Sprintf("%d", report.Stats.Deprecated)},
 		{"Parse Errors", fmt.Sprintf("%d", report.Stats.ParseErrors)},
 		{"Adopted Errors", fmt.Sprintf("%d", report.Stats.AdoptedErrors)},
 		{"Draft Warnings", fmt.Sprintf("%d", report.Stats.DraftWarnings)},
@@ -14,8 +14,14 @@ import (
 )
 
 var GenerateCommand = &cli.Command{
-	Name:   "generate",
-	Usage:  "Generate static site (dist)",
+	Name:  "generate",
+	Usage: "Generate static site (dist)",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "embeddings",
+			Usage: "Write document embeddings into kex.json (requires search.semantic.provider: http)",
+		},
+	},
 	Action: runGenerate,
 }
 
@@ -30,7 +36,7 @@ func runGenerate(c *cli.Context) error {
 	cfg := loadConfig(projectRoot)
 
 	// 2. Scan Documents
-	schema, err := scanDocuments(projectRoot, cfg)
+	schema, err := scanDocuments(projectRoot, cfg, c.Bool("embeddings"))
 	if err != nil {
 		return cli.Exit(err.Error(), 1)
 	}
@@ -66,7 +72,7 @@ func loadConfig(projectRoot string) config.Config {
 	return cfg
 }
 
-func scanDocuments(projectRoot string, cfg config.Config) (*fs.IndexSchema, error) {
+func scanDocuments(projectRoot string, cfg config.Config, withEmbeddings bool) (*fs.IndexSchema, error) {
 	// Scan single source
 	l := logger.NewStderrLogger()
 	spinner, _ := pterm.DefaultSpinner.Start("Scanning documents...")
@@ -80,6 +86,19 @@ func scanDocuments(projectRoot string, cfg config.Config) (*fs.IndexSchema, erro
 	provider := fs.NewLocalProvider(root, l)
 	repo := fs.New(provider, l)
 
+	if withEmbeddings {
+		if cfg.Search.Semantic.Provider != "http" {
+			spinner.Fail("--embeddings requires search.semantic.provider: http")
+			return nil, fmt.Errorf("embeddings can only be precomputed with an http embedding provider")
+		}
+		embedder, err := newEmbedder(cfg.Search.Semantic, repo.Tokenizer, projectRoot)
+		if err != nil {
+			spinner.Fail(err.Error())
+			return nil, err
+		}
+		repo.Embedder = embedder
+	}
+
 	if err := repo.Load(); err != nil {
 		return nil, fmt.Errorf("failed to load documents: %w", err)
 	}
@@ -89,6 +108,11 @@ func scanDocuments(projectRoot string, cfg config.Config) (*fs.IndexSchema, erro
 		return nil, fmt.Errorf("failed to export schema: %w", err)
 	}
 
+	if withEmbeddings && len(repo.VectorIndex) < len(repo.Documents) {
+		spinner.Fail("Failed to embed documents")
+		return nil, fmt.Errorf("embedded %d of %d documents; check the embeddings endpoint", len(repo.VectorIndex), len(repo.Documents))
+	}
+
 	spinner.Success("Documents scanned")
 
 	return schema, nil
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mew-ton/kex/internal/domain"
+
+	"github.com/pterm/pterm"
+	"github.com/urfave/cli/v2"
+)
+
+var ListCommand = &cli.Command{
+	Name:  "list",
+	Usage: "List documents by scope",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "scopes",
+			Usage: "Print only the scope tree (with document counts)",
+		},
+	},
+	Action: runList,
+}
+
+func runList(c *cli.Context) error {
+	projectRoot := c.Args().First()
+	if projectRoot == "" {
+		projectRoot = "."
+	}
+
+	cfg, err := resolveConfig(projectRoot)
+	if err != nil {
+		pterm.Warning.Printf("Failed to load config, using defaults: %v\n", err)
+	}
+
+	repo, err := loadRepository(projectRoot, cfg, false)
+	if err != nil {
+		return cli.Exit(fmt.Sprintf("Fatal: failed to load documents: %v", err), 1)
+	}
+
+	printScopeTree(repo.ScopeTree(), !c.Bool("scopes"))
+	return nil
+}
+
+// printScopeTree renders the scope hierarchy, optionally with the documents of each scope
+func printScopeTree(root *domain.ScopeNode, withDocuments bool) {
+	tree := pterm.TreeNode{Text: fmt.Sprintf("(root) (%d)", root.Count())}
+	tree.Children = scopeTreeChildren(root, withDocuments)
+	pterm.DefaultTree.WithRoot(tree).Render()
+}
+
+func scopeTreeChildren(node *domain.ScopeNode, withDocuments bool) []pterm.TreeNode {
+	var children []pterm.TreeNode
+	for _, child := range node.SortedChildren() {
+		children = append(children, pterm.TreeNode{
+			Text:     fmt.Sprintf("%s (%d)", child.Name, child.Count()),
+			Children: scopeTreeChildren(child, withDocuments),
+		})
+	}
+	if !withDocuments {
+		return children
+	}
+
+	docs := append([]*domain.Document(nil), node.Documents...)
+	sort.Slice(docs, func(a, b int) bool { return docs[a].ID < docs[b].ID })
+	for _, doc := range docs {
+		text := fmt.Sprintf("%s: %s", doc.ID, doc.Title)
+		if doc.IsPrompt() {
+			text += " (prompt)"
+		}
+		if doc.Status == domain.StatusDraft || doc.Status.IsRetired() {
+			text += fmt.Sprintf(" (%s)", doc.Status)
+		}
+		if doc.OverriddenBy != "" {
+			text += fmt.Sprintf(" (overridden by %s)", doc.OverriddenBy)
+		}
+		if doc.Waiver != "" {
+			text += " (waived)"
+		}
+		children = append(children, pterm.TreeNode{Text: text})
+	}
+	return children
+}
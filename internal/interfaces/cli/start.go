@@ -1,15 +1,21 @@
 package cli
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/mew-ton/kex/internal/domain"
 	"github.com/mew-ton/kex/internal/infrastructure/config"
+	"github.com/mew-ton/kex/internal/infrastructure/embedding"
 	"github.com/mew-ton/kex/internal/infrastructure/fs"
 	"github.com/mew-ton/kex/internal/infrastructure/logger"
 	"github.com/mew-ton/kex/internal/interfaces/mcp"
+	"github.com/mew-ton/kex/internal/usecase/catalog"
+	"github.com/mew-ton/kex/internal/usecase/graph"
+	"github.com/mew-ton/kex/internal/usecase/prompt"
 	"github.com/mew-ton/kex/internal/usecase/retrieve"
 	"github.com/mew-ton/kex/internal/usecase/search"
 	"github.com/mew-ton/kex/internal/usecase/validator"
@@ -33,6 +39,19 @@ var StartCommand = &cli.Command{
 			Name:  "remote-token",
 			Usage: "Bearer token for remote configuration",
 		},
+		&cli.BoolFlag{
+			Name:  "watch",
+			Value: true,
+			Usage: "Reload documents when local sources change (--watch=false to disable)",
+		},
+		&cli.StringFlag{
+			Name:  "http",
+			Usage: "Serve MCP over Streamable HTTP on this address (e.g. :8080) instead of stdio",
+		},
+		&cli.BoolFlag{
+			Name:  "legacy-sse",
+			Usage: "With --http, also serve the legacy HTTP+SSE transport on /sse for older clients",
+		},
 	},
 	Action: runStart,
 }
@@ -80,6 +99,11 @@ func runStart(c *cli.Context) error {
 	}
 	logger.SetGeneric(appLogger)
 
+	scopeMapper, err := newScopeMapper(cfg.ScopeMapping)
+	if err != nil {
+		return cli.Exit(err.Error(), 1)
+	}
+
 	// 4. Create and Prepare Repository
 	repo, loadedRoots, err := createRepository(cfg, appLogger, root)
 	if err != nil {
@@ -95,7 +119,15 @@ func runStart(c *cli.Context) error {
 
 	defer logger.Info("Kex Server Stopping...")
 
-	return startServer(repo)
+	live := fs.NewLiveRepository(repo, func() (*fs.Indexer, error) {
+		next, _, err := createRepository(cfg, appLogger, root)
+		return next, err
+	})
+	var watchRoots []string
+	if c.Bool("watch") {
+		watchRoots = localRoots(loadedRoots)
+	}
+	return startServer(live, scopeMapper, watchRoots, c.String("http"), mcp.HTTPOptions{LegacySSE: c.Bool("legacy-sse")})
 }
 
 func resolveCwd(c *cli.Context) (string, error) {
@@ -131,6 +163,35 @@ func createRepository(cfg config.Config, l logger.Logger, cwd string) (*fs.Index
 
 	compositeProvider := fs.NewCompositeProvider(providers)
 	repo := fs.New(compositeProvider, l)
+	repo.FieldWeights = resolveFieldWeights(cfg.Search)
+	repo.SeverityWeights = resolveSeverityWeights(cfg.Search)
+	repo.FullText = cfg.Search.FullText
+	repo.CacheDir = fs.CacheDir(cwd)
+	repo.Tokenizer = fs.NewTokenizer(cfg.Search.Language, cfg.Search.Stopwords)
+	repo.Synonyms = fs.Synonyms(cfg.Synonyms)
+	repo.Waivers, err = resolveWaivers(cfg.Waivers)
+	if err != nil {
+		return nil, nil, err
+	}
+	switch cfg.Search.ScopeMode {
+	case "", fs.ScopeModeStrict, fs.ScopeModeInherit:
+		repo.ScopeMode = cfg.Search.ScopeMode
+	default:
+		return nil, nil, fmt.Errorf("search.scopeMode: unknown mode %q (expected \"strict\" or \"inherit\")", cfg.Search.ScopeMode)
+	}
+	repo.Matching = fs.MatchOptions{
+		Prefix:   cfg.Search.Prefix,
+		Fuzzy:    cfg.Search.Fuzzy,
+		MaxEdits: cfg.Search.MaxEdits,
+	}
+	repo.Embedder, err = newEmbedder(cfg.Search.Semantic, repo.Tokenizer, cwd)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo.Semantic = fs.SemanticOptions{
+		Weight:        cfg.Search.Semantic.Weight,
+		MinSimilarity: cfg.Search.Semantic.MinSimilarity,
+	}
 
 	if err := repo.Load(); err != nil {
 		return nil, nil, fmt.Errorf("fatal: failed to load documents: %w", err)
@@ -143,6 +204,80 @@ func createRepository(cfg config.Config, l logger.Logger, cwd string) (*fs.Index
 	return repo, loadedRoots, nil
 }
 
+// resolveFieldWeights merges configured field weights over the defaults
+func resolveFieldWeights(cfg config.SearchConfig) map[fs.Field]float64 {
+	weights := make(map[fs.Field]float64, len(fs.DefaultFieldWeights))
+	for field, w := range fs.DefaultFieldWeights {
+		weights[field] = w
+	}
+	for field, w := range cfg.Weights {
+		weights[fs.Field(field)] = w
+	}
+	return weights
+}
+
+// resolveWaivers maps waived IDs to their reasons
+func resolveWaivers(waivers []config.Waiver) (map[string]string, error) {
+	reasons := make(map[string]string, len(waivers))
+	for _, w := range waivers {
+		if w.ID == "" {
+			return nil, fmt.Errorf("waivers: id is required")
+		}
+		if strings.TrimSpace(w.Reason) == "" {
+			return nil, fmt.Errorf("waivers: reason is required for %q", w.ID)
+		}
+		reasons[w.ID] = w.Reason
+	}
+	return reasons, nil
+}
+
+// resolveSeverityWeights merges configured severity weights over the defaults
+func resolveSeverityWeights(cfg config.SearchConfig) map[domain.Severity]float64 {
+	weights := make(map[domain.Severity]float64, len(fs.DefaultSeverityWeights))
+	for severity, w := range fs.DefaultSeverityWeights {
+		weights[severity] = w
+	}
+	for severity, w := range cfg.SeverityWeights {
+		weights[domain.Severity(severity)] = w
+	}
+	return weights
+}
+
+// newEmbedder creates the configured embedding provider (nil when semantic search is off).
+// Remote vectors are cached under .kex/cache/embeddings in the project root.
+func newEmbedder(cfg config.SemanticConfig, tokenizer fs.Tokenizer, projectRoot string) (embedding.Embedder, error) {
+	switch cfg.Provider {
+	case "":
+		return nil, nil
+	case "hashed":
+		return embedding.NewHashedEmbedder(cfg.Dimensions, tokenizer.Terms), nil
+	case "http":
+		if cfg.URL == "" || cfg.Model == "" {
+			return nil, fmt.Errorf("search.semantic: url and model are required for the http provider")
+		}
+		apiKey := cfg.APIKey
+		if envKey := os.Getenv("KEX_EMBEDDINGS_API_KEY"); envKey != "" {
+			apiKey = envKey
+		}
+		cacheDir := filepath.Join(projectRoot, ".kex", "cache", "embeddings")
+		return embedding.NewCachedEmbedder(embedding.NewHTTPEmbedder(cfg.URL, cfg.Model, apiKey), cacheDir), nil
+	}
+	return nil, fmt.Errorf("search.semantic: unknown provider %q (expected \"http\" or \"hashed\")", cfg.Provider)
+}
+
+// newScopeMapper puts the configured scope mapping in front of the built-in defaults
+func newScopeMapper(mapping []config.ScopeMappingRule) (*search.ScopeMapper, error) {
+	rules := make([]search.ScopeRule, 0, len(mapping))
+	for _, m := range mapping {
+		rules = append(rules, search.ScopeRule{Pattern: m.Pattern, Scopes: m.Scopes})
+	}
+	mapper, err := search.NewScopeMapper(rules)
+	if err != nil {
+		return nil, fmt.Errorf("scopeMapping: %w", err)
+	}
+	return mapper, nil
+}
+
 func logStartupStats(repo *fs.Indexer, loadedRoots []string) {
 	logger.Info("Kex Server Starting...")
 	logger.Info("Roots: %v", loadedRoots)
@@ -194,9 +329,19 @@ func resolveLogger(c *cli.Context, cfg config.Config, projectRoot string) (logge
 func validateRepository(repo *fs.Indexer) error {
 	v := validator.New([]validator.ValidationRule{
 		&validator.TitleRequiredRule{},
+		&validator.AppliesToPatternRule{},
+		&validator.SeverityRule{},
+		&validator.StatusRule{},
+		&validator.OverrideRule{},
+		&validator.PromptRule{Repo: repo},
 	})
 	report := v.Validate(repo)
 
+	// Duplicate IDs do not stop the server; the first source wins (kex check reports them as errors)
+	for _, w := range repo.Warnings {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", w)
+	}
+
 	if len(report.GlobalErrors) > 0 {
 		for _, e := range report.GlobalErrors {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", e)
@@ -218,18 +363,71 @@ func validateRepository(repo *fs.Indexer) error {
 	return nil
 }
 
-func startServer(repo *fs.Indexer) error {
+// startServer serves MCP on stdio, or over HTTP when httpAddr is set
+func startServer(repo *fs.LiveRepository, scopeMapper *search.ScopeMapper, watchRoots []string, httpAddr string, httpOpts mcp.HTTPOptions) error {
 	searchUC := search.New(repo)
+	searchUC.Scopes = scopeMapper
 	retrieveUC := retrieve.New(repo)
-	srv := mcp.New(searchUC, retrieveUC)
-	fmt.Fprintf(os.Stderr, "Server listening on stdio...\n")
-	if err := srv.Serve(); err != nil {
+	graphUC := graph.New(repo)
+	catalogUC := catalog.New(repo)
+	promptUC := prompt.New(repo)
+	srv := mcp.New(searchUC, retrieveUC, graphUC, catalogUC, promptUC)
+
+	if len(watchRoots) > 0 {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		watcher := &fs.Watcher{
+			Roots:    watchRoots,
+			OnChange: func() { reloadRepository(repo, srv) },
+		}
+		go watcher.Run(ctx)
+		logger.Info("Watching for changes: %v", watchRoots)
+	}
+
+	serve := srv.Serve
+	if httpAddr != "" {
+		fmt.Fprintf(os.Stderr, "Server listening on http://%s%s...\n", httpAddr, mcp.StreamablePath)
+		if httpOpts.LegacySSE {
+			fmt.Fprintf(os.Stderr, "Legacy SSE endpoint: http://%s%s\n", httpAddr, mcp.LegacySSEPath)
+		}
+		serve = func() error { return srv.ListenAndServe(httpAddr, httpOpts) }
+	} else {
+		fmt.Fprintf(os.Stderr, "Server listening on stdio...\n")
+	}
+	if err := serve(); err != nil {
 		logger.Error("Server error: %v", err)
 		return cli.Exit(fmt.Sprintf("Server error: %v", err), 1)
 	}
 	return nil
 }
 
+// reloadRepository rebuilds the index after a source changed.
+// A broken edit keeps the previous index serving until it is fixed.
+func reloadRepository(repo *fs.LiveRepository, srv *mcp.Server) {
+	logger.Info("Sources changed, reloading documents...")
+	changed, err := repo.Reload()
+	if err != nil {
+		logger.Error("Reload failed, keeping previous documents: %v", err)
+		return
+	}
+	logger.Info("Documents Reloaded: %d", len(repo.GetAll()))
+	if changed {
+		srv.NotifyDocumentsChanged()
+	}
+	srv.NotifyResourcesUpdated()
+}
+
+// localRoots filters out remote references (they cannot be watched)
+func localRoots(roots []string) []string {
+	var local []string
+	for _, root := range roots {
+		if !isURL(root) {
+			local = append(local, root)
+		}
+	}
+	return local
+}
+
 func loadProviders(cfg config.Config, l logger.Logger, cwd string) ([]fs.DocumentProvider, []string, error) {
 	var providers []fs.DocumentProvider
 	var loadedRoots []string
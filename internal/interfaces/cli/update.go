@@ -49,6 +49,7 @@ func runUpdate(c *cli.Context) error {
 		Cwd:         cwd,
 		LocalSource: sourceRoot,
 		References:  cfg.References,
+		Namespaces:  cfg.Namespaces,
 	}
 
 	if err := gen.Update(opts, updateConfig); err != nil {
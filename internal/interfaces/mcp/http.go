@@ -0,0 +1,297 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+// HTTP endpoints
+const (
+	StreamablePath     = "/mcp"      // Streamable HTTP transport
+	LegacySSEPath      = "/sse"      // Legacy HTTP+SSE transport: server event stream
+	LegacyMessagesPath = "/messages" // Legacy HTTP+SSE transport: client messages
+
+	sessionHeader  = "Mcp-Session-Id"
+	maxRequestSize = 4 << 20 // Bytes accepted in one POST body
+)
+
+// HTTPOptions configures the HTTP transport
+type HTTPOptions struct {
+	LegacySSE bool // Also serve the HTTP+SSE transport of protocol 2024-11-05 for older clients
+}
+
+// ListenAndServe starts the HTTP transport on the address (e.g. ":8080")
+func (s *Server) ListenAndServe(addr string, opts HTTPOptions) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           s.HTTPHandler(opts),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go s.reapSessions(ctx, sessionIdleTimeout)
+
+	if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		return fmt.Errorf("http server error: %w", err)
+	}
+	return nil
+}
+
+// HTTPHandler returns the handler of the MCP Streamable HTTP transport on StreamablePath,
+// and of the legacy HTTP+SSE transport when enabled.
+// Every session shares the same dispatch as stdio.
+func (s *Server) HTTPHandler(opts HTTPOptions) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StreamablePath, s.handleStreamable)
+	if opts.LegacySSE {
+		mux.HandleFunc(LegacySSEPath, s.handleLegacyStream)
+		mux.HandleFunc(LegacyMessagesPath, s.handleLegacyMessage)
+	}
+	return checkOrigin(mux)
+}
+
+// handleStreamable serves the Streamable HTTP transport:
+// POST sends a message, GET opens the stream of server notifications and DELETE ends the session
+func (s *Server) handleStreamable(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodPost:
+		s.handleStreamablePost(w, r)
+	case http.MethodGet:
+		sess, ok := s.requireSession(w, r)
+		if !ok {
+			return
+		}
+		if !accepts(r, "text/event-stream") {
+			http.Error(w, "Accept must include text/event-stream", http.StatusNotAcceptable)
+			return
+		}
+		s.stream(w, r, sess, nil)
+	case http.MethodDelete:
+		sess, ok := s.requireSession(w, r)
+		if !ok {
+			return
+		}
+		s.closeSession(sess.ID)
+		logger.Info("[MCP] Session Closed: %s", sess.ID)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleStreamablePost(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if !json.Valid(body) {
+		writeJSON(w, http.StatusBadRequest, response{JSONRPC: "2.0", Error: errParse})
+		return
+	}
+
+	// initialize starts a session; every later message must carry its ID
+	var sess *session
+	if isInitialize(body) {
+		sess = s.openSession(nil)
+		w.Header().Set(sessionHeader, sess.ID)
+		logger.Info("[MCP] Session Opened: %s", sess.ID)
+	} else {
+		var ok bool
+		if sess, ok = s.requireSession(w, r); !ok {
+			return
+		}
+	}
+
+	// The request ends with the POST: a client that disconnects cancels it
+	reply := s.handleMessage(r.Context(), sess, body)
+	if reply == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	// Clients accept JSON or an event stream; the stream carries the single reply
+	if !accepts(r, "application/json") && accepts(r, "text/event-stream") {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+		setStreamHeaders(w)
+		_ = writeEvent(w, flusher, "message", reply)
+	} else {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(reply)
+	}
+}
+
+// isInitialize reports whether the message is an initialize request (never part of a batch)
+func isInitialize(msg []byte) bool {
+	var probe struct {
+		Method string `json:"method"`
+	}
+	return json.Unmarshal(msg, &probe) == nil && probe.Method == "initialize"
+}
+
+// handleLegacyStream opens a session of the HTTP+SSE transport. The first event names
+// the endpoint to POST messages to; responses and notifications follow on the stream.
+func (s *Server) handleLegacyStream(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", "GET")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess := s.openSession(nil)
+	defer s.closeSession(sess.ID)
+	logger.Info("[MCP] Legacy Session Opened: %s", sess.ID)
+
+	endpoint := LegacyMessagesPath + "?sessionId=" + url.QueryEscape(sess.ID)
+	s.stream(w, r, sess, func(flusher http.Flusher) error {
+		return writeEvent(w, flusher, "endpoint", []byte(endpoint))
+	})
+}
+
+// handleLegacyMessage accepts a message of an HTTP+SSE session; its response is sent on the stream
+func (s *Server) handleLegacyMessage(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", "POST")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	sess, ok := s.lookupSession(r.URL.Query().Get("sessionId"))
+	if !ok {
+		http.Error(w, "Unknown session", http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	// The reply goes on the stream, so the request lives as long as the session rather than the POST
+	w.WriteHeader(http.StatusAccepted)
+	s.reply(sess, s.handleMessage(sess.ctx, sess, body))
+}
+
+// stream attaches an event stream to the session until the client disconnects.
+// first, if set, writes the opening events.
+func (s *Server) stream(w http.ResponseWriter, r *http.Request, sess *session, first func(http.Flusher) error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+	// Hold the session lock while opening so no message is written before the opening events
+	sess.mu.Lock()
+	if sess.out != nil {
+		sess.mu.Unlock()
+		http.Error(w, "A stream is already open for this session", http.StatusConflict)
+		return
+	}
+	setStreamHeaders(w)
+	w.WriteHeader(http.StatusOK)
+	if first != nil {
+		if err := first(flusher); err != nil {
+			sess.mu.Unlock()
+			return
+		}
+	} else {
+		flusher.Flush()
+	}
+	sess.out = func(msg []byte) error {
+		return writeEvent(w, flusher, "message", msg)
+	}
+	sess.mu.Unlock()
+
+	select {
+	case <-r.Context().Done():
+	case <-sess.ctx.Done():
+	}
+	sess.detach()
+}
+
+// requireSession returns the session named by the Mcp-Session-Id header, answering 400 or 404 otherwise
+func (s *Server) requireSession(w http.ResponseWriter, r *http.Request) (*session, bool) {
+	id := r.Header.Get(sessionHeader)
+	if id == "" {
+		http.Error(w, "Missing "+sessionHeader+" header", http.StatusBadRequest)
+		return nil, false
+	}
+	sess, ok := s.lookupSession(id)
+	if !ok {
+		http.Error(w, "Unknown session", http.StatusNotFound)
+		return nil, false
+	}
+	return sess, true
+}
+
+func setStreamHeaders(w http.ResponseWriter) {
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+}
+
+// writeEvent writes one server-sent event (encoded JSON-RPC messages never contain raw newlines)
+func writeEvent(w io.Writer, flusher http.Flusher, event string, data []byte) error {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// accepts reports whether the Accept header allows the media type (a missing header allows any)
+func accepts(r *http.Request, mediaType string) bool {
+	header := r.Header.Get("Accept")
+	if header == "" {
+		return true
+	}
+	for _, part := range strings.Split(header, ",") {
+		accepted := strings.TrimSpace(strings.SplitN(part, ";", 2)[0])
+		if accepted == mediaType || accepted == "*/*" {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOrigin rejects browser requests from pages not served by this machine (DNS rebinding protection).
+// Requests without an Origin header (non-browser clients) are allowed.
+func checkOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if origin := r.Header.Get("Origin"); origin != "" && !isLocalOrigin(origin) {
+			http.Error(w, "Origin not allowed", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func isLocalOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	if u.Hostname() == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(u.Hostname())
+	return ip != nil && ip.IsLoopback()
+}
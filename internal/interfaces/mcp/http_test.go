@@ -0,0 +1,282 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// MockRepository for testing
+type MockRepository struct {
+	Documents []*domain.Document
+}
+
+func (m *MockRepository) GetAll() []*domain.Document { return m.Documents }
+func (m *MockRepository) GetErrors() []error         { return nil }
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	for _, doc := range m.Documents {
+		if doc.ID == id {
+			return doc, true
+		}
+	}
+	return nil, false
+}
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                           { return nil }
+
+func newTestServer(t *testing.T, opts HTTPOptions) (*Server, *httptest.Server) {
+	repo := &MockRepository{Documents: []*domain.Document{
+		{ID: "errors", Title: "Error Handling", Status: domain.StatusAdopted, Body: "Wrap errors.\n"},
+	}}
+	srv := newServer(repo)
+	ts := httptest.NewServer(srv.HTTPHandler(opts))
+	t.Cleanup(ts.Close)
+	return srv, ts
+}
+
+func post(t *testing.T, url, sessionID, accept, body string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", accept)
+	if sessionID != "" {
+		req.Header.Set(sessionHeader, sessionID)
+	}
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { res.Body.Close() })
+	return res
+}
+
+// initialize opens a session and returns its ID
+func initialize(t *testing.T, ts *httptest.Server) string {
+	t.Helper()
+	res := post(t, ts.URL+StreamablePath, "", "application/json, text/event-stream", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+	if res.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", res.StatusCode)
+	}
+	id := res.Header.Get(sessionHeader)
+	if id == "" {
+		t.Fatal("expected a session ID")
+	}
+	if res := post(t, ts.URL+StreamablePath, id, "application/json", `{"jsonrpc":"2.0","method":"notifications/initialized"}`); res.StatusCode != http.StatusAccepted {
+		t.Fatalf("expected 202 for a notification, got %d", res.StatusCode)
+	}
+	return id
+}
+
+// readEvent reads the next server-sent event
+func readEvent(t *testing.T, r *bufio.Reader) (string, string) {
+	t.Helper()
+	var event, data string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatalf("failed to read event: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		switch {
+		case line == "" && data != "":
+			return event, data
+		case strings.HasPrefix(line, "event: "):
+			event = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = strings.TrimPrefix(line, "data: ")
+		}
+	}
+}
+
+func TestHTTPHandler_Streamable(t *testing.T) {
+	srv, ts := newTestServer(t, HTTPOptions{})
+	endpoint := ts.URL + StreamablePath
+
+	t.Run("it should answer requests of a session with JSON", func(t *testing.T) {
+		id := initialize(t, ts)
+		res := post(t, endpoint, id, "application/json, text/event-stream", `{"jsonrpc":"2.0","id":2,"method":"resources/read","params":{"uri":"kex://doc/errors"}}`)
+		if ct := res.Header.Get("Content-Type"); ct != "application/json" {
+			t.Fatalf("expected JSON, got %q", ct)
+		}
+		var body struct {
+			Result struct {
+				Contents []struct{ Text string } `json:"contents"`
+			} `json:"result"`
+		}
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body.Result.Contents) != 1 || !strings.Contains(body.Result.Contents[0].Text, "Wrap errors.") {
+			t.Errorf("unexpected result %+v", body.Result)
+		}
+	})
+
+	t.Run("it should stream the response when the client only accepts events", func(t *testing.T) {
+		id := initialize(t, ts)
+		res := post(t, endpoint, id, "text/event-stream", `{"jsonrpc":"2.0","id":3,"method":"ping"}`)
+		if ct := res.Header.Get("Content-Type"); ct != "text/event-stream" {
+			t.Fatalf("expected an event stream, got %q", ct)
+		}
+		event, data := readEvent(t, bufio.NewReader(res.Body))
+		if event != "message" || !strings.Contains(data, `"id":3`) {
+			t.Errorf("unexpected event %q: %s", event, data)
+		}
+	})
+
+	t.Run("it should answer a batch with an array", func(t *testing.T) {
+		id := initialize(t, ts)
+		res := post(t, endpoint, id, "application/json", `[{"jsonrpc":"2.0","id":6,"method":"ping"},{"jsonrpc":"2.0","id":7,"method":"ping"}]`)
+		var body []response
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if len(body) != 2 || stringifyID(body[0].ID) != "6" || stringifyID(body[1].ID) != "7" {
+			t.Errorf("unexpected responses %+v", body)
+		}
+	})
+
+	t.Run("it should return a parse error for invalid JSON", func(t *testing.T) {
+		res := post(t, endpoint, "", "application/json", `{"jsonrpc":`)
+		var body response
+		if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if res.StatusCode != http.StatusBadRequest || body.Error == nil || body.Error.Code != -32700 {
+			t.Errorf("expected 400 with a parse error, got %d %+v", res.StatusCode, body.Error)
+		}
+	})
+
+	t.Run("it should reject requests without a known session", func(t *testing.T) {
+		ping := `{"jsonrpc":"2.0","id":4,"method":"ping"}`
+		if res := post(t, endpoint, "", "application/json", ping); res.StatusCode != http.StatusBadRequest {
+			t.Errorf("expected 400 without a session, got %d", res.StatusCode)
+		}
+		if res := post(t, endpoint, "unknown", "application/json", ping); res.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404 for an unknown session, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("it should push notifications on the session stream", func(t *testing.T) {
+		id := initialize(t, ts)
+		req, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set(sessionHeader, id)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		srv.NotifyDocumentsChanged()
+		_, data := readEvent(t, bufio.NewReader(res.Body))
+		if !strings.Contains(data, "notifications/resources/list_changed") {
+			t.Errorf("expected list_changed, got %s", data)
+		}
+	})
+
+	t.Run("it should end a session on DELETE", func(t *testing.T) {
+		id := initialize(t, ts)
+		req, _ := http.NewRequest(http.MethodDelete, endpoint, nil)
+		req.Header.Set(sessionHeader, id)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", res.StatusCode)
+		}
+		if res := post(t, endpoint, id, "application/json", `{"jsonrpc":"2.0","id":5,"method":"ping"}`); res.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404 after DELETE, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("it should expire idle sessions without an open stream", func(t *testing.T) {
+		srv, ts := newTestServer(t, HTTPOptions{})
+		endpoint := ts.URL + StreamablePath
+		idle := initialize(t, ts)
+		active := initialize(t, ts)
+		req, _ := http.NewRequest(http.MethodGet, endpoint, nil)
+		req.Header.Set("Accept", "text/event-stream")
+		req.Header.Set(sessionHeader, active)
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+
+		expired := srv.expireSessions(time.Now().Add(time.Hour))
+		if len(expired) != 1 || expired[0] != idle {
+			t.Errorf("expected only %s to expire, got %v", idle, expired)
+		}
+		ping := `{"jsonrpc":"2.0","id":8,"method":"ping"}`
+		if res := post(t, endpoint, idle, "application/json", ping); res.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404 for an expired session, got %d", res.StatusCode)
+		}
+		if res := post(t, endpoint, active, "application/json", ping); res.StatusCode != http.StatusOK {
+			t.Errorf("expected the streaming session to stay open, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("it should reject requests from other sites", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`))
+		req.Header.Set("Origin", "https://evil.example")
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusForbidden {
+			t.Errorf("expected 403, got %d", res.StatusCode)
+		}
+	})
+}
+
+func TestHTTPHandler_LegacySSE(t *testing.T) {
+	t.Run("it should not serve the legacy endpoint unless enabled", func(t *testing.T) {
+		_, ts := newTestServer(t, HTTPOptions{})
+		res, err := http.Get(ts.URL + LegacySSEPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		if res.StatusCode != http.StatusNotFound {
+			t.Errorf("expected 404, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("it should answer posted messages on the event stream", func(t *testing.T) {
+		_, ts := newTestServer(t, HTTPOptions{LegacySSE: true})
+		res, err := http.Get(ts.URL + LegacySSEPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		events := bufio.NewReader(res.Body)
+
+		event, endpoint := readEvent(t, events)
+		if event != "endpoint" || !strings.HasPrefix(endpoint, LegacyMessagesPath+"?sessionId=") {
+			t.Fatalf("unexpected first event %q: %s", event, endpoint)
+		}
+
+		posted := post(t, ts.URL+endpoint, "", "application/json", `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`)
+		if posted.StatusCode != http.StatusAccepted {
+			t.Fatalf("expected 202, got %d", posted.StatusCode)
+		}
+		io.Copy(io.Discard, posted.Body)
+
+		if _, data := readEvent(t, events); !strings.Contains(data, `"protocolVersion"`) {
+			t.Errorf("expected the initialize result, got %s", data)
+		}
+	})
+}
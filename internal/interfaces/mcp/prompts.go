@@ -0,0 +1,75 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+func (s *Server) handleListPrompts() interface{} {
+	prompts := []map[string]interface{}{}
+	for _, doc := range s.PromptUC.List() {
+		prompt := map[string]interface{}{
+			"name":      doc.ID,
+			"arguments": promptArguments(doc.Arguments),
+		}
+		if doc.Description != "" {
+			prompt["description"] = doc.Description
+		}
+		prompts = append(prompts, prompt)
+	}
+
+	logger.Info("[Prompts] Listed %d prompts", len(prompts))
+	return map[string]interface{}{"prompts": prompts}
+}
+
+func (s *Server) handleGetPrompt(ctx context.Context, paramsRaw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		Name      string            `json:"name"`
+		Arguments map[string]string `json:"arguments"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil || params.Name == "" {
+		return nil, &rpcError{Code: -32602, Message: "Invalid params"}
+	}
+
+	logger.Info("[Prompts] Get: %s", params.Name)
+
+	result, err := s.PromptUC.Get(ctx, params.Name, params.Arguments)
+	if err != nil {
+		logger.Info("[Prompts] Result: %v", err)
+		if result.Found && ctx.Err() == nil {
+			// The prompt exists but could not be rendered with these arguments
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+		return nil, &rpcError{Code: -32603, Message: err.Error()}
+	}
+	if !result.Found {
+		logger.Info("[Prompts] Result: Not Found")
+		return nil, &rpcError{Code: -32602, Message: "Prompt not found"}
+	}
+
+	logger.Info("[Prompts] Result: Success (%d bytes)", len(result.Text))
+	return map[string]interface{}{
+		"description": result.Document.Title,
+		"messages": []map[string]interface{}{
+			{
+				"role":    "user",
+				"content": map[string]interface{}{"type": "text", "text": result.Text},
+			},
+		},
+	}, nil
+}
+
+func promptArguments(args []domain.PromptArgument) []map[string]interface{} {
+	list := []map[string]interface{}{}
+	for _, arg := range args {
+		item := map[string]interface{}{"name": arg.Name, "required": arg.Required}
+		if arg.Description != "" {
+			item["description"] = arg.Description
+		}
+		list = append(list, item)
+	}
+	return list
+}
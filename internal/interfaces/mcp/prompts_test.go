@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+func TestServer_HandleGetPrompt(t *testing.T) {
+	srv := newServer(&MockRepository{Documents: []*domain.Document{
+		{ID: "review", Kind: domain.KindPrompt, Title: "Review", Status: domain.StatusAdopted, Body: "Review {{.path}}.",
+			Arguments: []domain.PromptArgument{{Name: "path", Required: true}}},
+	}})
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name   string
+		ctx    context.Context
+		params string
+		code   int // 0 = success
+	}{
+		{name: "it should render the prompt", ctx: context.Background(), params: `{"name":"review","arguments":{"path":"main.go"}}`},
+		{name: "it should report unknown prompts as invalid params", ctx: context.Background(), params: `{"name":"missing"}`, code: -32602},
+		{name: "it should report missing arguments as invalid params", ctx: context.Background(), params: `{"name":"review"}`, code: -32602},
+		{name: "it should report a failed lookup as an internal error", ctx: cancelled, params: `{"name":"review","arguments":{"path":"main.go"}}`, code: -32603},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := srv.handleGetPrompt(tt.ctx, json.RawMessage(tt.params))
+			if tt.code == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error %+v", err)
+				}
+				return
+			}
+			if err == nil || err.Code != tt.code {
+				t.Errorf("expected error code %d, got %+v", tt.code, err)
+			}
+		})
+	}
+}
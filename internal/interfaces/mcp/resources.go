@@ -0,0 +1,229 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+// Resource URIs
+const (
+	docURIPrefix   = "kex://doc/"   // kex://doc/<id>, optionally with #<section>
+	scopeURIPrefix = "kex://scope/" // kex://scope/<scope>/<scope>/...
+	markdownMIME   = "text/markdown"
+)
+
+// errResourceNotFound is the error MCP defines for unknown resource URIs
+var errResourceNotFound = &rpcError{Code: -32002, Message: "Resource not found"}
+
+// docURI returns the resource URI of a document
+func docURI(id string) string {
+	return docURIPrefix + url.PathEscape(id)
+}
+
+// scopeURI returns the resource URI of a scope path
+func scopeURI(path []string) string {
+	escaped := make([]string, len(path))
+	for n, scope := range path {
+		escaped[n] = url.PathEscape(scope)
+	}
+	return scopeURIPrefix + strings.Join(escaped, "/")
+}
+
+func (s *Server) handleListResources() interface{} {
+	resources := []map[string]interface{}{}
+	for _, doc := range s.CatalogUC.Documents() {
+		resource := map[string]interface{}{
+			"uri":      docURI(doc.ID),
+			"name":     doc.Title,
+			"mimeType": markdownMIME,
+		}
+		if doc.Description != "" {
+			resource["description"] = doc.Description
+		}
+		resources = append(resources, resource)
+	}
+	for _, path := range s.CatalogUC.Scopes() {
+		resources = append(resources, map[string]interface{}{
+			"uri":         scopeURI(path),
+			"name":        "Scope: " + strings.Join(path, "/"),
+			"description": fmt.Sprintf("Guidelines in the %s scope and below it", strings.Join(path, "/")),
+			"mimeType":    markdownMIME,
+		})
+	}
+
+	logger.Info("[Resources] Listed %d resources", len(resources))
+	return map[string]interface{}{"resources": resources}
+}
+
+func (s *Server) handleListResourceTemplates() interface{} {
+	return map[string]interface{}{
+		"resourceTemplates": []map[string]interface{}{
+			{
+				"uriTemplate": docURIPrefix + "{id}",
+				"name":        "Guideline",
+				"description": "A guideline by document ID. Append #<section> to read one section (e.g. kex://doc/coding.go.errors#wrapping).",
+				"mimeType":    markdownMIME,
+			},
+			{
+				"uriTemplate": scopeURIPrefix + "{scope}",
+				"name":        "Scope",
+				"description": "The guidelines of a scope and its sub-scopes, as a list of document links. Separate nested scopes with '/' (e.g. kex://scope/coding/go).",
+				"mimeType":    markdownMIME,
+			},
+		},
+	}
+}
+
+func (s *Server) handleReadResource(ctx context.Context, paramsRaw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil || params.URI == "" {
+		return nil, &rpcError{Code: -32602, Message: "Invalid params"}
+	}
+
+	logger.Info("[Resources] Read: %s", params.URI)
+
+	text, err := s.readResource(ctx, params.URI)
+	if err != nil {
+		logger.Info("[Resources] Result: %s", err.Message)
+		return nil, err
+	}
+	return map[string]interface{}{
+		"contents": []map[string]interface{}{
+			{"uri": params.URI, "mimeType": markdownMIME, "text": text},
+		},
+	}, nil
+}
+
+func (s *Server) handleSubscribe(ctx context.Context, sess *session, paramsRaw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil || params.URI == "" {
+		return nil, &rpcError{Code: -32602, Message: "Invalid params"}
+	}
+
+	text, err := s.readResource(ctx, params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	sess.mu.Lock()
+	sess.subscriptions[params.URI] = text
+	sess.mu.Unlock()
+	logger.Info("[Resources] Subscribed: %s", params.URI)
+	return map[string]interface{}{}, nil
+}
+
+func (s *Server) handleUnsubscribe(sess *session, paramsRaw json.RawMessage) (interface{}, *rpcError) {
+	var params struct {
+		URI string `json:"uri"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil || params.URI == "" {
+		return nil, &rpcError{Code: -32602, Message: "Invalid params"}
+	}
+
+	sess.mu.Lock()
+	delete(sess.subscriptions, params.URI)
+	sess.mu.Unlock()
+	logger.Info("[Resources] Unsubscribed: %s", params.URI)
+	return map[string]interface{}{}, nil
+}
+
+// changedSubscriptions re-reads the resources the session subscribed to and returns the URIs whose content changed.
+// A resource that disappeared counts as changed; reading it then reports it as not found.
+// Resources are read without holding the session lock, which also serializes writes to the client.
+func (s *Server) changedSubscriptions(sess *session) []string {
+	sess.mu.Lock()
+	last := make(map[string]string, len(sess.subscriptions))
+	for uri, text := range sess.subscriptions {
+		last[uri] = text
+	}
+	sess.mu.Unlock()
+
+	current := make(map[string]string, len(last))
+	for uri := range last {
+		text, err := s.readResource(sess.ctx, uri)
+		if err != nil {
+			text = ""
+		}
+		current[uri] = text
+	}
+
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	var changed []string
+	for uri, text := range current {
+		// Leave subscriptions that were dropped or renewed while reading
+		if sent, ok := sess.subscriptions[uri]; !ok || sent != last[uri] || text == sent {
+			continue
+		}
+		sess.subscriptions[uri] = text
+		changed = append(changed, uri)
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// readResource renders the content of a kex:// URI
+func (s *Server) readResource(ctx context.Context, uri string) (string, *rpcError) {
+	switch {
+	case strings.HasPrefix(uri, docURIPrefix):
+		rest, fragment, _ := strings.Cut(strings.TrimPrefix(uri, docURIPrefix), "#")
+		id, err := url.PathUnescape(rest)
+		if err != nil || id == "" {
+			return "", errResourceNotFound
+		}
+		if fragment != "" {
+			id = domain.SectionID(id, fragment)
+		}
+
+		result, err := s.RetrieveUC.Execute(ctx, id)
+		if err != nil {
+			return "", &rpcError{Code: -32603, Message: err.Error()}
+		}
+		if !result.Found || (result.Slug != "" && result.Section == nil) {
+			return "", errResourceNotFound
+		}
+		return formatDocument(result), nil
+
+	case strings.HasPrefix(uri, scopeURIPrefix):
+		var path []string
+		for _, segment := range strings.Split(strings.Trim(strings.TrimPrefix(uri, scopeURIPrefix), "/"), "/") {
+			scope, err := url.PathUnescape(segment)
+			if err != nil {
+				return "", errResourceNotFound
+			}
+			path = append(path, scope)
+		}
+
+		result := s.CatalogUC.Scope(path)
+		if !result.Found {
+			return "", errResourceNotFound
+		}
+		return formatScope(result.Path, result.Documents), nil
+	}
+	return "", errResourceNotFound
+}
+
+// formatScope lists the documents of a scope with their resource URIs
+func formatScope(path []string, docs []*domain.Document) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Scope: %s\n\nDocuments (%d):\n", strings.Join(path, "/"), len(docs))
+	for _, doc := range docs {
+		fmt.Fprintf(&b, "- [%s](%s) (ID: `%s`)", doc.Title, docURI(doc.ID), doc.ID)
+		if doc.Description != "" {
+			b.WriteString(": " + doc.Description)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
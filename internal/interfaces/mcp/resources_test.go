@@ -0,0 +1,45 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+func TestServer_NotifyResourcesUpdated(t *testing.T) {
+	doc := &domain.Document{ID: "errors", Title: "Error Handling", Status: domain.StatusAdopted, Body: "Wrap errors.\n"}
+	srv := newServer(&MockRepository{Documents: []*domain.Document{doc}})
+
+	var sent []string
+	sess := srv.openSession(func(msg []byte) error {
+		sent = append(sent, string(msg))
+		return nil
+	})
+	sess.setInitialized()
+
+	id := json.RawMessage("1")
+	params := json.RawMessage(`{"uri":"kex://doc/errors"}`)
+	if res := srv.handleRequest(context.Background(), sess, request{JSONRPC: "2.0", ID: &id, Method: "resources/subscribe", Params: params}); res == nil || res.Error != nil {
+		t.Fatalf("failed to subscribe: %+v", res)
+	}
+
+	t.Run("it should notify a subscribed resource whose body changed", func(t *testing.T) {
+		sent = nil
+		doc.Body = "Wrap errors with context.\n"
+		srv.NotifyResourcesUpdated()
+		if len(sent) != 1 || !strings.Contains(sent[0], "notifications/resources/updated") || !strings.Contains(sent[0], "kex://doc/errors") {
+			t.Errorf("expected one update for kex://doc/errors, got %q", sent)
+		}
+	})
+
+	t.Run("it should not notify while the content is unchanged", func(t *testing.T) {
+		sent = nil
+		srv.NotifyResourcesUpdated()
+		if len(sent) != 0 {
+			t.Errorf("expected no notification, got %q", sent)
+		}
+	})
+}
@@ -2,11 +2,20 @@ package mcp
 
 import (
 	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
+	"sync"
 
+	"github.com/mew-ton/kex/internal/domain"
 	"github.com/mew-ton/kex/internal/infrastructure/logger"
+	"github.com/mew-ton/kex/internal/usecase/catalog"
+	"github.com/mew-ton/kex/internal/usecase/graph"
+	"github.com/mew-ton/kex/internal/usecase/prompt"
 	"github.com/mew-ton/kex/internal/usecase/retrieve"
 	"github.com/mew-ton/kex/internal/usecase/search"
 )
@@ -15,12 +24,22 @@ import (
 type Server struct {
 	SearchUC   *search.UseCase
 	RetrieveUC *retrieve.UseCase
+	GraphUC    *graph.UseCase
+	CatalogUC  *catalog.UseCase
+	PromptUC   *prompt.UseCase
+
+	mu       sync.Mutex          // Guards sessions
+	sessions map[string]*session // Connected clients by session ID
 }
 
-func New(searchUC *search.UseCase, retrieveUC *retrieve.UseCase) *Server {
+func New(searchUC *search.UseCase, retrieveUC *retrieve.UseCase, graphUC *graph.UseCase, catalogUC *catalog.UseCase, promptUC *prompt.UseCase) *Server {
 	return &Server{
 		SearchUC:   searchUC,
 		RetrieveUC: retrieveUC,
+		GraphUC:    graphUC,
+		CatalogUC:  catalogUC,
+		PromptUC:   promptUC,
+		sessions:   make(map[string]*session),
 	}
 }
 
@@ -39,35 +58,194 @@ type response struct {
 	ID      *json.RawMessage `json:"id"`
 }
 
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params,omitempty"`
+}
+
 type rpcError struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// supportedProtocolVersions are the MCP revisions the server speaks, newest first
+var supportedProtocolVersions = []string{"2025-06-18", "2025-03-26", "2024-11-05"}
+
+// structuredContentVersion is the first revision with structured tool results; older clients only read the text
+const structuredContentVersion = "2025-06-18"
+
+// negotiateProtocolVersion returns the version requested in the initialize params when supported,
+// otherwise the latest one (the client then decides whether it can continue)
+func negotiateProtocolVersion(params json.RawMessage) string {
+	var p struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	_ = json.Unmarshal(params, &p)
+	for _, v := range supportedProtocolVersions {
+		if v == p.ProtocolVersion {
+			return v
+		}
+	}
+	return supportedProtocolVersions[0]
+}
+
+// Standard JSON-RPC errors
+var (
+	errParse          = &rpcError{Code: -32700, Message: "Parse error"}
+	errInvalidRequest = &rpcError{Code: -32600, Message: "Invalid Request"}
+)
+
 // Serve starts the JSON-RPC loop on Stdio
 func (s *Server) Serve() error {
-	scanner := bufio.NewScanner(os.Stdin)
-	// Increase buffer size if needed, but default is usually fine for messages
-	// MCP messages can be large (tool outputs), but requests are usually small.
+	return s.serveStream(os.Stdin, os.Stdout)
+}
+
+// serveStream reads newline-delimited messages of one client until r ends, and writes replies to w.
+// Requests and batches are handled concurrently, so replies may come out of order;
+// notifications are handled in the order they arrive (e.g. notifications/cancelled right away).
+func (s *Server) serveStream(r io.Reader, w io.Writer) error {
+	sess := s.openSession(func(msg []byte) error {
+		// One Write per message; the session lock keeps messages from interleaving
+		_, err := w.Write(append(msg[:len(msg):len(msg)], '\n'))
+		return err
+	})
+	defer s.closeSession(sess.ID)
+
+	var wg sync.WaitGroup
+	defer wg.Wait()
+
+	// bufio.Reader instead of Scanner: messages may be of any length
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if msg := bytes.TrimSpace(line); len(msg) > 0 {
+			if isNotification(msg) {
+				s.reply(sess, s.handleMessage(sess.ctx, sess, msg))
+			} else {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					s.reply(sess, s.handleMessage(sess.ctx, sess, msg))
+				}()
+			}
+		}
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read error: %w", err)
+		}
+	}
+}
+
+// isNotification reports whether the message is a single notification (a message without an ID)
+func isNotification(msg []byte) bool {
+	var probe struct {
+		ID     *json.RawMessage `json:"id"`
+		Method string           `json:"method"`
+	}
+	return json.Unmarshal(msg, &probe) == nil && probe.ID == nil && probe.Method != ""
+}
+
+// handleMessage handles a message (a request, a notification or a batch of them) and returns
+// the encoded reply: a response, or an array of responses for a batch (nil = nothing to send).
+// ctx is the parent of the requests' contexts.
+func (s *Server) handleMessage(ctx context.Context, sess *session, msg []byte) []byte {
+	msg = bytes.TrimSpace(msg)
+	if len(msg) > 0 && msg[0] == '[' {
+		return s.handleBatch(ctx, sess, msg)
+	}
+	res := s.handleSingle(ctx, sess, msg)
+	if res == nil {
+		return nil
+	}
+	return encodeResponses(*res)
+}
+
+// handleBatch handles the messages of a batch concurrently; the responses keep the order of the requests
+func (s *Server) handleBatch(ctx context.Context, sess *session, msg []byte) []byte {
+	var batch []json.RawMessage
+	if err := json.Unmarshal(msg, &batch); err != nil {
+		return encodeResponses(response{JSONRPC: "2.0", Error: errParse})
+	}
+	if len(batch) == 0 {
+		return encodeResponses(response{JSONRPC: "2.0", Error: errInvalidRequest})
+	}
 
-	for scanner.Scan() {
-		line := scanner.Bytes()
-		s.handleMessage(line)
+	results := make([]*response, len(batch))
+	var wg sync.WaitGroup
+	for i, item := range batch {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = s.handleSingle(ctx, sess, item)
+		}()
 	}
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("read error: %w", err)
+	wg.Wait()
+
+	var responses []response
+	for _, res := range results {
+		if res != nil {
+			responses = append(responses, *res)
+		}
 	}
-	return nil
+	if len(responses) == 0 {
+		// A batch of notifications gets no reply
+		return nil
+	}
+	return encodeResponses(responses...)
 }
 
-func (s *Server) handleMessage(msg []byte) {
+// handleSingle validates and dispatches one message of any transport (nil = no response).
+// A request cancelled before it completes gets no response.
+func (s *Server) handleSingle(ctx context.Context, sess *session, msg json.RawMessage) *response {
+	if !json.Valid(msg) {
+		return &response{JSONRPC: "2.0", Error: errParse}
+	}
 	var req request
-	if err := json.Unmarshal(msg, &req); err != nil {
-		// Parse error
-		fmt.Fprintf(os.Stderr, "failed to parse request: %v\n", err)
-		return
+	if err := json.Unmarshal(msg, &req); err != nil || !req.valid() {
+		res := response{JSONRPC: "2.0", Error: errInvalidRequest}
+		if err == nil && validID(req.ID) {
+			res.ID = req.ID
+		}
+		return &res
+	}
+
+	if req.ID == nil {
+		s.handleRequest(ctx, sess, req)
+		return nil
 	}
 
+	ctx, end := sess.begin(ctx, *req.ID)
+	defer end()
+	res := s.handleRequest(ctx, sess, req)
+	if ctx.Err() != nil {
+		logger.Info("[MCP] Request Cancelled: ID=%s", stringifyID(req.ID))
+		return nil
+	}
+	return res
+}
+
+// valid reports whether the request is a JSON-RPC 2.0 request or notification
+func (req request) valid() bool {
+	return req.JSONRPC == "2.0" && req.Method != "" && (req.ID == nil || validID(req.ID))
+}
+
+// validID reports whether the ID is a string or a number, as JSON-RPC requires
+func validID(id *json.RawMessage) bool {
+	if id == nil || len(*id) == 0 {
+		return false
+	}
+	switch c := (*id)[0]; {
+	case c == '"', c == '-', c >= '0' && c <= '9':
+		return true
+	}
+	return false
+}
+
+// handleRequest dispatches a request of the session to its handler, whatever the transport
+func (s *Server) handleRequest(ctx context.Context, sess *session, req request) *response {
 	res := response{
 		JSONRPC: "2.0",
 		ID:      req.ID,
@@ -81,29 +259,56 @@ func (s *Server) handleMessage(msg []byte) {
 
 	switch req.Method {
 	case "initialize":
+		version := negotiateProtocolVersion(req.Params)
+		sess.setProtocol(version)
 		result = map[string]interface{}{
-			"protocolVersion": "2024-11-05", // Latest known or 0.1.0
+			"protocolVersion": version,
 			"serverInfo": map[string]string{
 				"name":    "kex",
 				"version": "2.0.0",
 			},
 			"capabilities": map[string]interface{}{
 				"tools": map[string]interface{}{},
+				"resources": map[string]interface{}{
+					"subscribe":   true,
+					"listChanged": true,
+				},
+				"prompts": map[string]interface{}{
+					"listChanged": true,
+				},
 			},
 		}
 	case "notifications/initialized":
 		// No response needed
-		return
+		sess.setInitialized()
+		return nil
+	case "notifications/cancelled":
+		s.handleCancelled(sess, req.Params)
+		return nil
 	case "ping":
 		result = map[string]string{}
 	case "tools/list":
-		result = s.handleListTools()
+		result = s.handleListTools(sess)
 	case "tools/call":
-		result, err = s.handleCallTool(req.Params)
+		result, err = s.handleCallTool(ctx, sess, req.Params)
+	case "resources/list":
+		result = s.handleListResources()
+	case "resources/templates/list":
+		result = s.handleListResourceTemplates()
+	case "resources/read":
+		result, err = s.handleReadResource(ctx, req.Params)
+	case "resources/subscribe":
+		result, err = s.handleSubscribe(ctx, sess, req.Params)
+	case "resources/unsubscribe":
+		result, err = s.handleUnsubscribe(sess, req.Params)
+	case "prompts/list":
+		result = s.handleListPrompts()
+	case "prompts/get":
+		result, err = s.handleGetPrompt(ctx, req.Params)
 	default:
 		// Ignore unknown notifications
 		if req.ID == nil {
-			return
+			return nil
 		}
 		err = &rpcError{Code: -32601, Message: "Method not found"}
 	}
@@ -113,18 +318,51 @@ func (s *Server) handleMessage(msg []byte) {
 	} else {
 		res.Result = result
 	}
+	return &res
+}
 
-	s.sendResponse(res)
+// handleCancelled cancels the request named by notifications/cancelled; unknown or completed requests are ignored
+func (s *Server) handleCancelled(sess *session, paramsRaw json.RawMessage) {
+	var params struct {
+		RequestID json.RawMessage `json:"requestId"`
+		Reason    string          `json:"reason"`
+	}
+	if err := json.Unmarshal(paramsRaw, &params); err != nil || len(params.RequestID) == 0 {
+		return
+	}
+	if sess.cancelRequest(params.RequestID) {
+		logger.Info("[MCP] Cancel Requested: ID=%s, Reason=%q", params.RequestID, params.Reason)
+	}
 }
 
-func (s *Server) sendResponse(res response) {
-	bytes, err := json.Marshal(res)
+// reply writes an encoded reply to the session's stream (stdio or legacy SSE)
+func (s *Server) reply(sess *session, msg []byte) {
+	if msg == nil {
+		return
+	}
+	if err := sess.send(msg); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to send response: %v\n", err)
+	}
+}
+
+// encodeResponses encodes one response, or several as a batch reply, and logs them (nil on failure)
+func encodeResponses(responses ...response) []byte {
+	var v interface{} = responses
+	if len(responses) == 1 {
+		v = responses[0]
+	}
+	encoded, err := json.Marshal(v)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "failed to marshal response: %v\n", err)
-		return
+		return nil
 	}
-	fmt.Printf("%s\n", bytes)
+	for _, res := range responses {
+		logResponse(res)
+	}
+	return encoded
+}
 
+func logResponse(res response) {
 	status := "Success"
 	if res.Error != nil {
 		status = fmt.Sprintf("Error (%d: %s)", res.Error.Code, res.Error.Message)
@@ -132,6 +370,37 @@ func (s *Server) sendResponse(res response) {
 	logger.Info("[MCP] Response Sent: ID=%s, Status=%s", stringifyID(res.ID), status)
 }
 
+// NotifyDocumentsChanged tells every client that the document set changed (e.g. after a reload),
+// including prompts.
+// It is safe to call from any goroutine; nothing is sent to a client before it finished initialization.
+func (s *Server) NotifyDocumentsChanged() {
+	for _, sess := range s.openSessions() {
+		s.sendNotification(sess, "notifications/resources/list_changed", nil)
+		s.sendNotification(sess, "notifications/prompts/list_changed", nil)
+	}
+}
+
+// NotifyResourcesUpdated tells every client which of its subscribed resources now read differently.
+// Call it after every reload: a body edit changes a resource without changing the document list.
+func (s *Server) NotifyResourcesUpdated() {
+	for _, sess := range s.openSessions() {
+		for _, uri := range s.changedSubscriptions(sess) {
+			s.sendNotification(sess, "notifications/resources/updated", map[string]string{"uri": uri})
+		}
+	}
+}
+
+func (s *Server) sendNotification(sess *session, method string, params interface{}) {
+	bytes, err := json.Marshal(notification{JSONRPC: "2.0", Method: method, Params: params})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to marshal notification: %v\n", err)
+		return
+	}
+	if sess.notify(bytes) {
+		logger.Info("[MCP] Notification Sent: %s", method)
+	}
+}
+
 func stringifyID(id *json.RawMessage) string {
 	if id == nil {
 		return "null"
@@ -141,124 +410,243 @@ func stringifyID(id *json.RawMessage) string {
 
 // -- Handlers --
 
-func (s *Server) handleListTools() interface{} {
-	return map[string]interface{}{
-		"tools": []map[string]interface{}{
-			{
-				"name":        "search_documents",
-				"description": "Search project guidelines using keywords",
-				"inputSchema": map[string]interface{}{
-					"type": "object",
-					"properties": map[string]interface{}{
-						"keywords": map[string]interface{}{
-							"type": "array",
-							"items": map[string]string{
-								"type": "string",
-							},
-							"description": "Keywords related to the coding task",
-						},
-						"filePath": map[string]interface{}{
-							"type":        "string",
-							"description": "The path of the file you are working on. Used for scope filtering.",
-						},
-						"exactScopeMatch": map[string]interface{}{
-							"type":        "boolean",
-							"description": "If true, treats keywords as exact scope names to match.",
+func (s *Server) handleListTools(sess *session) interface{} {
+	tools := []map[string]interface{}{
+		{
+			"name":         "search_documents",
+			"description":  "Search project guidelines using keywords or a query expression. Results are ranked by relevance score; mandatory (severity: must) guidelines rank higher.",
+			"outputSchema": searchOutputSchema(),
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"keywords": map[string]interface{}{
+						"type": "array",
+						"items": map[string]string{
+							"type": "string",
 						},
+						"description": "Keywords related to the coding task (a document matching any keyword is returned)",
+					},
+					"query": map[string]interface{}{
+						"type":        "string",
+						"description": "Query expression to narrow results, e.g. 'error handling scope:go -legacy status:draft keyword:testing'. Terms are combined with AND; use OR, -term or NOT to exclude, parentheses and \"quoted phrases\". Fields: title, description, keyword, body, scope, status (deprecated and archived documents are only returned when filtering by status), severity (must, should, may), id. Use severity:must to list only mandatory rules.",
+					},
+					"filePath": map[string]interface{}{
+						"type":        "string",
+						"description": "The path of the file you are working on. Used for scope filtering and to include guidelines targeting this file.",
+					},
+					"exactScopeMatch": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, treats keywords as exact scope names to match.",
+					},
+					"prefix": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, keywords also match terms starting with them (e.g. 'test' matches 'testing'). Defaults to the server configuration.",
+					},
+					"fuzzy": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, keywords also match terms within a small edit distance (typo tolerance). Defaults to the server configuration.",
+					},
+					"limit": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Maximum number of documents to return (default %d, max %d).", search.DefaultLimit, search.MaxLimit),
+					},
+					"offset": map[string]interface{}{
+						"type":        "integer",
+						"description": "Number of ranked documents to skip.",
+					},
+					"cursor": map[string]interface{}{
+						"type":        "string",
+						"description": "Cursor returned by a previous search to fetch the next page (same query arguments required).",
+					},
+					"minScore": map[string]interface{}{
+						"type":        "number",
+						"description": "Drop documents whose relevance score is below this value.",
 					},
-					"required": []string{"keywords"},
 				},
 			},
-			{
-				"name":        "read_document",
-				"description": "Read the full content of a specific document",
-				"inputSchema": map[string]interface{}{
-					"type": "object",
-					"properties": map[string]interface{}{
-						"id": map[string]interface{}{
-							"type":        "string",
-							"description": "Document ID",
-						},
+		},
+		{
+			"name":         "read_document",
+			"description":  "Read the full content of a specific document, or one section of it. For long documents, request the outline first and read only the section you need. Deprecated documents redirect to their replacement.",
+			"outputSchema": documentOutputSchema(),
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Document ID, optionally with a section (e.g. 'coding.go.errors#wrapping')",
+					},
+					"section": map[string]interface{}{
+						"type":        "string",
+						"description": "Section to read, as listed in the outline (e.g. 'wrapping'). Overrides a section in the ID.",
+					},
+					"outline": map[string]interface{}{
+						"type":        "boolean",
+						"description": "If true, returns only the headings with their section IDs and sizes.",
 					},
-					"required": []string{"id"},
 				},
+				"required": []string{"id"},
 			},
 		},
+		{
+			"name":        "get_related_documents",
+			"description": "List documents linked to a document through related, supersedes and requires (in both directions). Use it to find prerequisites and replacements before following a guideline.",
+			"inputSchema": map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"id": map[string]interface{}{
+						"type":        "string",
+						"description": "Document ID",
+					},
+					"depth": map[string]interface{}{
+						"type":        "integer",
+						"description": fmt.Sprintf("Number of relations to follow (default %d, max %d).", graph.DefaultDepth, graph.MaxDepth),
+					},
+				},
+				"required": []string{"id"},
+			},
+		},
+	}
+	if !sess.structuredContent() {
+		for _, tool := range tools {
+			delete(tool, "outputSchema")
+		}
 	}
+	return map[string]interface{}{"tools": tools}
 }
 
-func (s *Server) handleCallTool(paramsRaw json.RawMessage) (interface{}, *rpcError) {
+func (s *Server) handleCallTool(ctx context.Context, sess *session, paramsRaw json.RawMessage) (interface{}, *rpcError) {
 	var params struct {
 		Name      string          `json:"name"`
 		Arguments json.RawMessage `json:"arguments"`
 	}
 	if err := json.Unmarshal(paramsRaw, &params); err != nil {
-		return nil, &rpcError{Code: -32700, Message: "Invalid params"}
+		return nil, &rpcError{Code: -32602, Message: "Invalid params"}
 	}
 
+	var result interface{}
+	var err *rpcError
 	switch params.Name {
 	case "search_documents":
-		return s.handleSearchDocuments(params.Arguments)
+		result, err = s.handleSearchDocuments(ctx, params.Arguments)
 	case "read_document":
-		return s.handleReadDocument(params.Arguments)
+		result, err = s.handleReadDocument(ctx, params.Arguments)
+	case "get_related_documents":
+		result, err = s.handleGetRelatedDocuments(ctx, params.Arguments)
 	default:
 		return nil, &rpcError{Code: -32601, Message: "Tool not found"}
 	}
+
+	if content, ok := result.(map[string]interface{}); ok && !sess.structuredContent() {
+		delete(content, "structuredContent")
+	}
+	return result, err
 }
 
-func (s *Server) handleSearchDocuments(argsRaw json.RawMessage) (interface{}, *rpcError) {
+func (s *Server) handleSearchDocuments(ctx context.Context, argsRaw json.RawMessage) (interface{}, *rpcError) {
 	var args struct {
 		Keywords        []string `json:"keywords"`
+		Query           string   `json:"query"`
 		FilePath        string   `json:"filePath"`
 		ExactScopeMatch bool     `json:"exactScopeMatch"`
+		Prefix          *bool    `json:"prefix"`
+		Fuzzy           *bool    `json:"fuzzy"`
+		Limit           int      `json:"limit"`
+		Offset          int      `json:"offset"`
+		Cursor          string   `json:"cursor"`
+		MinScore        float64  `json:"minScore"`
 	}
 	if err := json.Unmarshal(argsRaw, &args); err != nil {
-		return nil, &rpcError{Code: -32700, Message: "Invalid arguments"}
+		return nil, &rpcError{Code: -32602, Message: "Invalid arguments"}
 	}
 
 	// Use Search Use Case
-	result := s.SearchUC.Execute(args.Keywords, args.FilePath, args.ExactScopeMatch)
+	if len(args.Keywords) == 0 && args.Query == "" {
+		return nil, &rpcError{Code: -32602, Message: "Either keywords or query is required"}
+	}
+
+	result, err := s.SearchUC.Execute(ctx, search.Request{
+		Keywords:        args.Keywords,
+		Query:           args.Query,
+		FilePath:        args.FilePath,
+		ExactScopeMatch: args.ExactScopeMatch,
+		Prefix:          args.Prefix,
+		Fuzzy:           args.Fuzzy,
+		Limit:           args.Limit,
+		Offset:          args.Offset,
+		Cursor:          args.Cursor,
+		MinScore:        args.MinScore,
+	})
+
+	logger.Info("[Tool:search_documents] Query: Keywords=%v, Query=%q, FilePath=%s, Exact=%v", args.Keywords, args.Query, args.FilePath, args.ExactScopeMatch)
 
-	logger.Info("[Tool:search_documents] Query: Keywords=%v, FilePath=%s, Exact=%v", args.Keywords, args.FilePath, args.ExactScopeMatch)
+	if err != nil {
+		logger.Info("[Tool:search_documents] Result: %v", err)
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": err.Error()},
+			},
+			"isError": true,
+		}, nil
+	}
 
 	var foundIDs []string
 	for _, doc := range result.Documents {
 		foundIDs = append(foundIDs, doc.ID)
 	}
-	logger.Info("[Tool:search_documents] Result: Found %d documents, IDs=%v", len(result.Documents), foundIDs)
+	logger.Info("[Tool:search_documents] Result: Found %d documents (total %d), IDs=%v", len(result.Documents), result.Total, foundIDs)
 
 	var content []map[string]interface{}
 
 	if len(result.Documents) == 0 {
+		text := "No matching documents found."
+		if result.Total > 0 {
+			text = fmt.Sprintf("No more documents: all %d matching documents were already returned.", result.Total)
+		}
 		content = append(content, map[string]interface{}{
 			"type": "text",
-			"text": "No matching documents found.",
+			"text": text,
 		})
 	} else {
-		text := "Found documents:\n"
-		for _, doc := range result.Documents {
-			text += fmt.Sprintf("- **%s** (ID: `%s`): %s\n", doc.Title, doc.ID, doc.Description)
-		}
 		content = append(content, map[string]interface{}{
 			"type": "text",
-			"text": text,
+			"text": formatSearchResult(result),
 		})
 	}
 
-	return map[string]interface{}{"content": content}, nil
+	return map[string]interface{}{
+		"content":           content,
+		"structuredContent": newSearchOutput(result),
+	}, nil
 }
 
-func (s *Server) handleReadDocument(argsRaw json.RawMessage) (interface{}, *rpcError) {
+func (s *Server) handleReadDocument(ctx context.Context, argsRaw json.RawMessage) (interface{}, *rpcError) {
 	var args struct {
-		ID string `json:"id"`
+		ID      string `json:"id"`
+		Section string `json:"section"`
+		Outline bool   `json:"outline"`
 	}
 	if err := json.Unmarshal(argsRaw, &args); err != nil {
-		return nil, &rpcError{Code: -32700, Message: "Invalid arguments"}
+		return nil, &rpcError{Code: -32602, Message: "Invalid arguments"}
 	}
 
-	logger.Info("[Tool:read_document] ID: %s", args.ID)
+	logger.Info("[Tool:read_document] ID: %s, Section: %s, Outline: %v", args.ID, args.Section, args.Outline)
 
-	result := s.RetrieveUC.Execute(args.ID)
+	id := args.ID
+	if args.Section != "" {
+		docID, _ := domain.SplitSectionID(id)
+		id = domain.SectionID(docID, args.Section)
+	}
+	result, err := s.RetrieveUC.Execute(ctx, id)
+	if err != nil {
+		logger.Info("[Tool:read_document] Result: %v", err)
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": err.Error()},
+			},
+			"isError": true,
+		}, nil
+	}
 	if !result.Found {
 		logger.Info("[Tool:read_document] Result: Not Found")
 		return map[string]interface{}{
@@ -269,14 +657,268 @@ func (s *Server) handleReadDocument(argsRaw json.RawMessage) (interface{}, *rpcE
 		}, nil
 	}
 
-	logger.Info("[Tool:read_document] Result: Success (%d bytes)", len(result.Document.Body))
+	if len(result.Redirects) > 0 {
+		logger.Info("[Tool:read_document] Redirected: %s -> %s", args.ID, result.Document.ID)
+	}
+	if result.Document.Waiver != "" {
+		logger.Info("[Tool:read_document] Result: Waived")
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": formatDocument(result)},
+			},
+			"structuredContent": newDocumentOutput(result),
+		}, nil
+	}
+	sections := result.Document.Sections()
+	if result.Slug != "" && result.Section == nil && !args.Outline {
+		logger.Info("[Tool:read_document] Result: Section Not Found")
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": fmt.Sprintf("Section %q not found.\n\n%s", result.Slug, formatOutline(result.Document, sections))},
+			},
+			"isError": true,
+		}, nil
+	}
+
+	if args.Outline {
+		logger.Info("[Tool:read_document] Result: Outline (%d sections)", len(sections))
+		structured := newDocumentOutput(result)
+		structured.Outline = newOutline(result.Document, sections)
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": formatRedirectNotice(result) + formatOutline(result.Document, sections)},
+			},
+			"structuredContent": structured,
+		}, nil
+	}
+
+	text := formatDocument(result)
+	structured := newDocumentOutput(result)
+	structured.Content = result.Document.Body
+	if result.Section != nil {
+		structured.Section = result.Section.Slug
+		structured.Content = result.Section.Content(result.Document.Body)
+		logger.Info("[Tool:read_document] Result: Section %s (%d bytes)", result.Section.Slug, result.Section.End-result.Section.Start)
+	} else {
+		logger.Info("[Tool:read_document] Result: Success (%d bytes)", len(result.Document.Body))
+		// The see-also list is optional: a cancelled traversal just leaves it out
+		if related, err := s.GraphUC.Execute(ctx, result.Document.ID, 1); err == nil && len(related.Neighbours) > 0 {
+			text = strings.TrimRight(text, "\n") + "\n\n" + formatSeeAlso(related.Neighbours)
+			for _, n := range related.Neighbours {
+				structured.Related = append(structured.Related, n.Document.ID)
+			}
+		}
+	}
 
 	return map[string]interface{}{
 		"content": []map[string]interface{}{
 			{
 				"type": "text",
-				"text": fmt.Sprintf("# %s\n\n%s", result.Document.Title, result.Document.Body),
+				"text": text,
 			},
 		},
+		"structuredContent": structured,
 	}, nil
 }
+
+func (s *Server) handleGetRelatedDocuments(ctx context.Context, argsRaw json.RawMessage) (interface{}, *rpcError) {
+	var args struct {
+		ID    string `json:"id"`
+		Depth int    `json:"depth"`
+	}
+	if err := json.Unmarshal(argsRaw, &args); err != nil {
+		return nil, &rpcError{Code: -32602, Message: "Invalid arguments"}
+	}
+
+	logger.Info("[Tool:get_related_documents] ID: %s, Depth: %d", args.ID, args.Depth)
+
+	result, err := s.GraphUC.Execute(ctx, args.ID, args.Depth)
+	if err != nil {
+		logger.Info("[Tool:get_related_documents] Result: %v", err)
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": err.Error()},
+			},
+			"isError": true,
+		}, nil
+	}
+	if !result.Found {
+		logger.Info("[Tool:get_related_documents] Result: Not Found")
+		return map[string]interface{}{
+			"content": []map[string]interface{}{
+				{"type": "text", "text": "Document not found."},
+			},
+			"isError": true,
+		}, nil
+	}
+
+	logger.Info("[Tool:get_related_documents] Result: %d documents", len(result.Neighbours))
+
+	text := fmt.Sprintf("No documents are linked to **%s** (ID: `%s`).", result.Document.Title, result.Document.ID)
+	if len(result.Neighbours) > 0 {
+		text = formatNeighbourhood(result)
+	}
+	return map[string]interface{}{
+		"content": []map[string]interface{}{
+			{"type": "text", "text": text},
+		},
+	}, nil
+}
+
+// formatRedirectNotice explains a redirect from a retired or overridden document,
+// or warns that the document is retired
+func formatRedirectNotice(result retrieve.Result) string {
+	if len(result.Redirects) > 0 {
+		from := result.Redirects[0]
+		if from.OverriddenBy != "" {
+			how := "replaces it"
+			if result.Document.EffectiveOverrideMode() == domain.OverrideExtend {
+				how = "extends it with a project addendum"
+			}
+			return fmt.Sprintf("> **Local variant**: This project overrides **%s** (ID: `%s`); `%s` %s. Follow this document instead.\n\n",
+				from.Title, from.ID, result.Document.ID, how)
+		}
+		return fmt.Sprintf("> **Redirected**: **%s** (ID: `%s`) is %s and has been replaced by `%s`. Follow this document instead.\n\n",
+			from.Title, from.ID, from.Status, result.Document.ID)
+	}
+	if result.Document.Status.IsRetired() {
+		return fmt.Sprintf("> **%s**: This document is no longer followed and has no replacement. Do not apply it to new code.\n\n",
+			capitalize(string(result.Document.Status)))
+	}
+	return ""
+}
+
+// formatDocument renders a retrieved document (or its requested section) below the redirect notice.
+// A waived document renders as the waiver alone.
+func formatDocument(result retrieve.Result) string {
+	doc := result.Document
+	if doc.Waiver != "" {
+		return fmt.Sprintf("> **Waived**: **%s** (ID: `%s`) is waived in this project: %s\n\nDo not apply this guideline here.",
+			doc.Title, doc.ID, doc.Waiver)
+	}
+	content := doc.Body
+	if result.Section != nil {
+		content = result.Section.Content(doc.Body)
+	}
+	return formatRedirectNotice(result) + fmt.Sprintf("# %s\n\n%s", doc.Title, content)
+}
+
+// formatOutline lists the headings of a document, nested by level, with their section IDs and sizes
+func formatOutline(doc *domain.Document, sections []domain.Section) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Outline of **%s** (ID: `%s`, ~%d tokens):\n", doc.Title, doc.ID, doc.EstimatedTokens())
+	if len(sections) == 0 {
+		b.WriteString("\nThe document has no headings. Read it without a section.\n")
+		return b.String()
+	}
+
+	top := sections[0].Level
+	for _, s := range sections {
+		top = min(top, s.Level)
+	}
+	for _, s := range sections {
+		indent := strings.Repeat("  ", s.Level-top)
+		fmt.Fprintf(&b, "%s- %s (`%s`, ~%d tokens)\n", indent, s.Title, domain.SectionID(doc.ID, s.Slug), s.EstimatedTokens())
+	}
+	b.WriteString("\nCall read_document with a section ID to read only that section.\n")
+	return b.String()
+}
+
+// formatSeeAlso renders the direct neighbours appended to read_document
+func formatSeeAlso(neighbours []graph.Neighbour) string {
+	var b strings.Builder
+	b.WriteString("---\n\n## See also\n\n")
+	for _, n := range neighbours {
+		fmt.Fprintf(&b, "- %s: %s\n", relationLabel(n.Relation), formatLinkedDocument(n.Document))
+	}
+	return b.String()
+}
+
+// formatNeighbourhood renders the neighbours as a tree below the document they were reached from
+func formatNeighbourhood(result graph.Result) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Documents linked to **%s** (ID: `%s`):\n", result.Document.Title, result.Document.ID)
+
+	children := make(map[string][]graph.Neighbour)
+	for _, n := range result.Neighbours {
+		children[n.From] = append(children[n.From], n)
+	}
+	var write func(from string, indent string)
+	write = func(from string, indent string) {
+		for _, n := range children[from] {
+			fmt.Fprintf(&b, "%s- %s: %s\n", indent, relationLabel(n.Relation), formatLinkedDocument(n.Document))
+			write(n.Document.ID, indent+"  ")
+		}
+	}
+	write(result.Document.ID, "")
+	return b.String()
+}
+
+func formatLinkedDocument(doc *domain.Document) string {
+	text := fmt.Sprintf("**%s** (ID: `%s`)", doc.Title, doc.ID)
+	if doc.Description != "" {
+		text += ": " + doc.Description
+	}
+	return text
+}
+
+// relationLabel capitalizes a relation for display ("superseded by" -> "Superseded by")
+func relationLabel(r domain.Relation) string {
+	return capitalize(string(r))
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+// formatSearchResult renders one page of results with the metadata agents need to budget reads
+func formatSearchResult(result search.Result) string {
+	var b strings.Builder
+	first, last := result.Offset+1, result.Offset+len(result.Documents)
+	fmt.Fprintf(&b, "Found %d documents (showing %d-%d, most relevant first):\n", result.Total, first, last)
+
+	for _, doc := range result.Documents {
+		fmt.Fprintf(&b, "- **%s** (ID: `%s`, Score: %.2f): %s\n", doc.Title, doc.ID, doc.Score, doc.Description)
+		if doc.Snippet != "" {
+			fmt.Fprintf(&b, "  - Match (%s): %s\n", doc.MatchedField, doc.Snippet)
+		}
+		if doc.Section != "" {
+			fmt.Fprintf(&b, "  - Best section: `%s`\n", domain.SectionID(doc.ID, doc.Section))
+		}
+
+		var meta []string
+		if doc.Overrides != "" {
+			meta = append(meta, fmt.Sprintf("Local variant of `%s` (%s)", doc.Overrides, doc.EffectiveOverrideMode()))
+		}
+		if doc.Status.IsRetired() {
+			status := "Status: " + string(doc.Status)
+			if doc.ReplacedBy != "" {
+				status += fmt.Sprintf(" (replaced by `%s`)", doc.ReplacedBy)
+			}
+			meta = append(meta, status)
+		}
+		if doc.Severity != "" {
+			meta = append(meta, "Severity: "+string(doc.Severity))
+		}
+		if len(doc.Scopes) > 0 {
+			meta = append(meta, "Scopes: "+strings.Join(doc.Scopes, "/"))
+		}
+		if len(doc.Keywords) > 0 {
+			meta = append(meta, "Keywords: "+strings.Join(doc.Keywords, ", "))
+		}
+		if tokens := doc.EstimatedTokens(); tokens > 0 {
+			meta = append(meta, fmt.Sprintf("Size: ~%d tokens", tokens))
+		}
+		if len(meta) > 0 {
+			fmt.Fprintf(&b, "  - %s\n", strings.Join(meta, " | "))
+		}
+	}
+
+	if result.NextCursor != "" {
+		fmt.Fprintf(&b, "\nMore results available. Call search_documents again with cursor `%s` to continue.\n", result.NextCursor)
+	}
+	return b.String()
+}
@@ -0,0 +1,214 @@
+package mcp
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/usecase/catalog"
+	"github.com/mew-ton/kex/internal/usecase/graph"
+	"github.com/mew-ton/kex/internal/usecase/prompt"
+	"github.com/mew-ton/kex/internal/usecase/retrieve"
+	"github.com/mew-ton/kex/internal/usecase/search"
+)
+
+// blockingRepository holds every search until released
+type blockingRepository struct {
+	MockRepository
+	started chan struct{}
+	release chan struct{}
+}
+
+func (m *blockingRepository) Search(query domain.SearchQuery) []domain.SearchResult {
+	m.started <- struct{}{}
+	<-m.release
+	return nil
+}
+
+func newServer(repo domain.DocumentRepository) *Server {
+	return New(search.New(repo), retrieve.New(repo), graph.New(repo), catalog.New(repo), prompt.New(repo))
+}
+
+// serve runs the stdio loop on the input and returns the lines written in reply
+func serve(t *testing.T, input string) []string {
+	t.Helper()
+	var out strings.Builder
+	if err := newServer(&MockRepository{}).serveStream(strings.NewReader(input), &out); err != nil {
+		t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func TestServer_ServeStream(t *testing.T) {
+	t.Run("it should answer a batch in order, leaving out notifications", func(t *testing.T) {
+		lines := serve(t, `[{"jsonrpc":"2.0","id":1,"method":"ping"},{"jsonrpc":"2.0","method":"notifications/initialized"},{"jsonrpc":"2.0","id":"b","method":"nope"}]`+"\n")
+		if len(lines) != 1 {
+			t.Fatalf("expected one reply, got %q", lines)
+		}
+		var replies []response
+		if err := json.Unmarshal([]byte(lines[0]), &replies); err != nil {
+			t.Fatalf("expected an array of responses: %v", err)
+		}
+		if len(replies) != 2 || stringifyID(replies[0].ID) != "1" || stringifyID(replies[1].ID) != `"b"` {
+			t.Fatalf("unexpected replies %s", lines[0])
+		}
+		if replies[1].Error == nil || replies[1].Error.Code != -32601 {
+			t.Errorf("expected method not found, got %+v", replies[1].Error)
+		}
+	})
+
+	t.Run("it should not reply to a batch of notifications", func(t *testing.T) {
+		if lines := serve(t, `[{"jsonrpc":"2.0","method":"notifications/initialized"}]`+"\n"); lines[0] != "" {
+			t.Errorf("expected no reply, got %q", lines)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		input    string
+		wantCode int
+		wantID   string
+	}{
+		{
+			name:     "it should return a parse error for invalid JSON",
+			input:    `{"jsonrpc":"2.0","id":1,"method":`,
+			wantCode: -32700,
+			wantID:   "null",
+		},
+		{
+			name:     "it should return a parse error for an invalid batch",
+			input:    `[{"jsonrpc":"2.0","id":1,"method":"ping"},`,
+			wantCode: -32700,
+			wantID:   "null",
+		},
+		{
+			name:     "it should reject an empty batch",
+			input:    `[]`,
+			wantCode: -32600,
+			wantID:   "null",
+		},
+		{
+			name:     "it should reject a message that is not an object",
+			input:    `42`,
+			wantCode: -32600,
+			wantID:   "null",
+		},
+		{
+			name:     "it should reject a request without a method",
+			input:    `{"jsonrpc":"2.0","id":7}`,
+			wantCode: -32600,
+			wantID:   "7",
+		},
+		{
+			name:     "it should reject a request of another protocol version",
+			input:    `{"jsonrpc":"1.0","id":"x","method":"ping"}`,
+			wantCode: -32600,
+			wantID:   `"x"`,
+		},
+		{
+			name:     "it should reject an ID that is not a string or a number",
+			input:    `{"jsonrpc":"2.0","id":{"a":1},"method":"ping"}`,
+			wantCode: -32600,
+			wantID:   "null",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := serve(t, tt.input+"\n")
+			var res response
+			if err := json.Unmarshal([]byte(lines[0]), &res); err != nil {
+				t.Fatalf("expected a response, got %q", lines)
+			}
+			if res.Error == nil || res.Error.Code != tt.wantCode {
+				t.Fatalf("expected error %d, got %+v", tt.wantCode, res.Error)
+			}
+			if got := stringifyID(res.ID); got != tt.wantID {
+				t.Errorf("expected ID %s, got %s", tt.wantID, got)
+			}
+		})
+	}
+
+	t.Run("it should read messages of any length", func(t *testing.T) {
+		padding := strings.Repeat("x", 1<<20)
+		lines := serve(t, `{"jsonrpc":"2.0","id":1,"method":"ping","params":{"padding":"`+padding+`"}}`+"\n")
+		if len(lines) != 1 || !strings.Contains(lines[0], `"id":1`) {
+			t.Errorf("expected the ping response, got %q", lines)
+		}
+	})
+}
+
+func TestServer_Cancellation(t *testing.T) {
+	t.Run("it should drop the response of a cancelled request while answering others", func(t *testing.T) {
+		repo := &blockingRepository{started: make(chan struct{}), release: make(chan struct{})}
+		inR, inW := io.Pipe()
+		outR, outW := io.Pipe()
+		done := make(chan error, 1)
+		go func() {
+			done <- newServer(repo).serveStream(inR, outW)
+			outW.Close()
+		}()
+		out := bufio.NewReader(outR)
+		send := func(msg string) {
+			if _, err := io.WriteString(inW, msg+"\n"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		send(`{"jsonrpc":"2.0","id":1,"method":"tools/call","params":{"name":"search_documents","arguments":{"keywords":["errors"]}}}`)
+		<-repo.started
+		send(`{"jsonrpc":"2.0","method":"notifications/cancelled","params":{"requestId":1,"reason":"timeout"}}`)
+		// The search is still running, yet later requests are answered
+		send(`{"jsonrpc":"2.0","id":2,"method":"ping"}`)
+		line, err := out.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(line, `"id":2`) {
+			t.Fatalf("expected the ping response, got %s", line)
+		}
+
+		close(repo.release)
+		inW.Close()
+		rest, _ := io.ReadAll(out)
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+		if len(rest) != 0 {
+			t.Errorf("expected no response to the cancelled request, got %s", rest)
+		}
+	})
+}
+
+func TestServer_Initialize(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested string
+		want      string
+	}{
+		{name: "it should echo a supported version", requested: "2025-03-26", want: "2025-03-26"},
+		{name: "it should accept the oldest supported version", requested: "2024-11-05", want: "2024-11-05"},
+		{name: "it should answer the latest version to an unknown one", requested: "2099-01-01", want: supportedProtocolVersions[0]},
+		{name: "it should answer the latest version when none is requested", requested: "", want: supportedProtocolVersions[0]},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines := serve(t, `{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"`+tt.requested+`"}}`+"\n")
+			var reply struct {
+				Result struct {
+					ProtocolVersion string `json:"protocolVersion"`
+				} `json:"result"`
+			}
+			if err := json.Unmarshal([]byte(lines[0]), &reply); err != nil {
+				t.Fatal(err)
+			}
+			if reply.Result.ProtocolVersion != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, reply.Result.ProtocolVersion)
+			}
+		})
+	}
+}
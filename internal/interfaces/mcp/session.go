@@ -0,0 +1,219 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mew-ton/kex/internal/infrastructure/logger"
+)
+
+// sessionIdleTimeout ends HTTP sessions that sent no message for this long and have no open stream
+const sessionIdleTimeout = 30 * time.Minute
+
+// session is the state of one connected client: the stdio peer or an HTTP session
+type session struct {
+	ID     string
+	ctx    context.Context // Cancelled when the session ends
+	cancel context.CancelFunc
+
+	mu            sync.Mutex         // Guards the fields below and serializes writes to the client
+	out           func([]byte) error // Writes a message to the client (nil = no open stream)
+	initialized   bool               // Set once the client sent notifications/initialized
+	protocol      string             // Protocol version negotiated in initialize ("" until then)
+	subscriptions map[string]string  // Subscribed resource URI -> Content last sent to the client
+
+	lastSeen atomic.Int64 // Unix nanoseconds of the last message from the client
+
+	requestsMu sync.Mutex                    // Guards requests (separate from mu so slow writes don't delay requests)
+	requests   map[string]context.CancelFunc // In-flight request ID -> cancels its context
+}
+
+// openSession registers a new client; out may be nil until the client opens a stream
+func (s *Server) openSession(out func([]byte) error) *session {
+	ctx, cancel := context.WithCancel(context.Background())
+	sess := &session{
+		ID:            newSessionID(),
+		ctx:           ctx,
+		cancel:        cancel,
+		out:           out,
+		subscriptions: make(map[string]string),
+		requests:      make(map[string]context.CancelFunc),
+	}
+	sess.touch()
+	s.mu.Lock()
+	s.sessions[sess.ID] = sess
+	s.mu.Unlock()
+	return sess
+}
+
+// closeSession forgets a client, ends its open stream and cancels its requests; it receives no more notifications
+func (s *Server) closeSession(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sess, ok := s.sessions[id]; ok {
+		delete(s.sessions, id)
+		sess.cancel()
+	}
+}
+
+// lookupSession returns the session with the ID, marking it as active
+func (s *Server) lookupSession(id string) (*session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sess, ok := s.sessions[id]
+	if ok {
+		sess.touch()
+	}
+	return sess, ok
+}
+
+// expireSessions ends the sessions idle since before the cutoff and returns their IDs.
+// Sessions with an open stream are kept: the client is still connected.
+func (s *Server) expireSessions(cutoff time.Time) []string {
+	var expired []string
+	for _, sess := range s.openSessions() {
+		if sess.idleSince(cutoff) {
+			s.closeSession(sess.ID)
+			expired = append(expired, sess.ID)
+		}
+	}
+	return expired
+}
+
+// reapSessions expires idle sessions until the context is done
+func (s *Server) reapSessions(ctx context.Context, timeout time.Duration) {
+	ticker := time.NewTicker(min(timeout, time.Minute))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			for _, id := range s.expireSessions(now.Add(-timeout)) {
+				logger.Info("[MCP] Session Expired: %s", id)
+			}
+		}
+	}
+}
+
+// openSessions returns every connected client
+func (s *Server) openSessions() []*session {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	sessions := make([]*session, 0, len(s.sessions))
+	for _, sess := range s.sessions {
+		sessions = append(sessions, sess)
+	}
+	return sessions
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("failed to generate session ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+func (c *session) touch() {
+	c.lastSeen.Store(time.Now().UnixNano())
+}
+
+// idleSince reports whether the client sent nothing since the cutoff and has no open stream
+func (c *session) idleSince(cutoff time.Time) bool {
+	c.mu.Lock()
+	streaming := c.out != nil
+	c.mu.Unlock()
+	return !streaming && c.lastSeen.Load() < cutoff.UnixNano()
+}
+
+func (c *session) setInitialized() {
+	c.mu.Lock()
+	c.initialized = true
+	c.mu.Unlock()
+}
+
+func (c *session) setProtocol(version string) {
+	c.mu.Lock()
+	c.protocol = version
+	c.mu.Unlock()
+}
+
+// structuredContent reports whether the negotiated protocol has structured tool results
+// (outputSchema and structuredContent)
+func (c *session) structuredContent() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.protocol >= structuredContentVersion
+}
+
+// detach closes the stream; later messages are dropped until the client opens another one
+func (c *session) detach() {
+	c.mu.Lock()
+	c.out = nil
+	c.mu.Unlock()
+}
+
+// send writes a message to the client's stream
+func (c *session) send(msg []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.out == nil {
+		return fmt.Errorf("session %s has no open stream", c.ID)
+	}
+	return c.out(msg)
+}
+
+// notify writes a notification, reporting whether it was sent.
+// Nothing is sent before the client finished initialization or while it has no open stream.
+func (c *session) notify(msg []byte) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.initialized || c.out == nil {
+		return false
+	}
+	return c.out(msg) == nil
+}
+
+// begin derives the context of a request from parent; notifications/cancelled with its ID cancels it.
+// end must be called once the request is handled.
+func (c *session) begin(parent context.Context, id json.RawMessage) (ctx context.Context, end func()) {
+	ctx, cancel := context.WithCancel(parent)
+	key := requestKey(id)
+	c.requestsMu.Lock()
+	c.requests[key] = cancel
+	c.requestsMu.Unlock()
+	return ctx, func() {
+		c.requestsMu.Lock()
+		delete(c.requests, key)
+		c.requestsMu.Unlock()
+		cancel()
+	}
+}
+
+// cancelRequest cancels the in-flight request with the ID, reporting whether there was one
+func (c *session) cancelRequest(id json.RawMessage) bool {
+	c.requestsMu.Lock()
+	defer c.requestsMu.Unlock()
+	cancel, ok := c.requests[requestKey(id)]
+	if ok {
+		cancel()
+	}
+	return ok
+}
+
+// requestKey normalizes a request ID so that equal IDs match however they are spaced
+func requestKey(id json.RawMessage) string {
+	var b bytes.Buffer
+	if err := json.Compact(&b, id); err != nil {
+		return string(id)
+	}
+	return b.String()
+}
@@ -0,0 +1,205 @@
+package mcp
+
+import (
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/usecase/retrieve"
+	"github.com/mew-ton/kex/internal/usecase/search"
+)
+
+// Structured tool results (structuredContent), sent alongside the markdown text
+// that older clients read. Each matches the outputSchema of its tool.
+
+// searchOutput is the structured result of search_documents
+type searchOutput struct {
+	Documents  []documentSummary `json:"documents"` // Ordered by descending score
+	Total      int               `json:"total"`     // Matching documents before paging
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+type documentSummary struct {
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Scopes      []string `json:"scopes"`
+	Keywords    []string `json:"keywords"`
+	Score       float64  `json:"score"`
+	Status      string   `json:"status"`
+	Source      string   `json:"source"` // Local root or URL the document was loaded from
+	Path        string   `json:"path"`   // Path of the markdown file within its source
+}
+
+// documentOutput is the structured result of read_document
+type documentOutput struct {
+	ID             string         `json:"id"`
+	Title          string         `json:"title"`
+	Description    string         `json:"description"`
+	Scopes         []string       `json:"scopes"`
+	Keywords       []string       `json:"keywords"`
+	Status         string         `json:"status"`
+	Severity       string         `json:"severity"`
+	Source         string         `json:"source"`
+	Path           string         `json:"path"`
+	Section        string         `json:"section,omitempty"`        // Slug of the returned section
+	Content        string         `json:"content"`                  // Body or section ("" for an outline or a waived document)
+	Outline        []outlineEntry `json:"outline,omitempty"`        // Headings, when the outline was requested
+	RedirectedFrom []string       `json:"redirectedFrom,omitempty"` // IDs of retired or overridden documents followed to this one
+	Waiver         string         `json:"waiver,omitempty"`         // Reason the guideline is waived in this project
+	Related        []string       `json:"related,omitempty"`        // IDs of directly linked documents (full reads only)
+}
+
+type outlineEntry struct {
+	ID     string `json:"id"` // Section ID to read (e.g. coding.go.errors#wrapping)
+	Title  string `json:"title"`
+	Level  int    `json:"level"`
+	Tokens int    `json:"tokens"`
+}
+
+func newSearchOutput(result search.Result) searchOutput {
+	out := searchOutput{Documents: []documentSummary{}, Total: result.Total, NextCursor: result.NextCursor}
+	for _, doc := range result.Documents {
+		out.Documents = append(out.Documents, documentSummary{
+			ID:          doc.ID,
+			Title:       doc.Title,
+			Description: doc.Description,
+			Scopes:      nonNil(doc.Scopes),
+			Keywords:    nonNil(doc.Keywords),
+			Score:       doc.Score,
+			Status:      string(doc.Status),
+			Source:      doc.Source,
+			Path:        doc.SourcePath,
+		})
+	}
+	return out
+}
+
+// newDocumentOutput describes a retrieved document; content is left to the caller
+func newDocumentOutput(result retrieve.Result) documentOutput {
+	doc := result.Document
+	out := documentOutput{
+		ID:          doc.ID,
+		Title:       doc.Title,
+		Description: doc.Description,
+		Scopes:      nonNil(doc.Scopes),
+		Keywords:    nonNil(doc.Keywords),
+		Status:      string(doc.Status),
+		Severity:    string(doc.EffectiveSeverity()),
+		Source:      doc.Source,
+		Path:        doc.SourcePath,
+		Waiver:      doc.Waiver,
+	}
+	for _, from := range result.Redirects {
+		out.RedirectedFrom = append(out.RedirectedFrom, from.ID)
+	}
+	return out
+}
+
+func newOutline(doc *domain.Document, sections []domain.Section) []outlineEntry {
+	outline := []outlineEntry{}
+	for _, s := range sections {
+		outline = append(outline, outlineEntry{
+			ID:     domain.SectionID(doc.ID, s.Slug),
+			Title:  s.Title,
+			Level:  s.Level,
+			Tokens: s.EstimatedTokens(),
+		})
+	}
+	return outline
+}
+
+// nonNil keeps empty lists as [] rather than null
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func searchOutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"documents": map[string]interface{}{
+				"type":        "array",
+				"description": "Matching documents, most relevant first.",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"id":          stringSchema("Document ID, to pass to read_document"),
+						"title":       stringSchema(""),
+						"description": stringSchema(""),
+						"scopes":      stringArraySchema(),
+						"keywords":    stringArraySchema(),
+						"score":       map[string]interface{}{"type": "number", "description": "Relevance score"},
+						"status":      stringSchema("draft, adopted, deprecated or archived"),
+						"source":      stringSchema("Local directory or URL the document was loaded from"),
+						"path":        stringSchema("Path of the markdown file within its source"),
+					},
+					"required": []string{"id", "title", "description", "scopes", "keywords", "score", "status", "source", "path"},
+				},
+			},
+			"total":      map[string]interface{}{"type": "integer", "description": "Number of matching documents before paging"},
+			"nextCursor": stringSchema("Cursor for the next page (absent on the last page)"),
+		},
+		"required": []string{"documents", "total"},
+	}
+}
+
+func documentOutputSchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"id":          stringSchema("ID of the returned document (the replacement when redirected)"),
+			"title":       stringSchema(""),
+			"description": stringSchema(""),
+			"scopes":      stringArraySchema(),
+			"keywords":    stringArraySchema(),
+			"status":      stringSchema("draft, adopted, deprecated or archived"),
+			"severity":    stringSchema("must, should or may"),
+			"source":      stringSchema("Local directory or URL the document was loaded from"),
+			"path":        stringSchema("Path of the markdown file within its source"),
+			"section":     stringSchema("Slug of the returned section"),
+			"content":     stringSchema("Markdown of the document or section (empty for an outline or a waived document)"),
+			"outline": map[string]interface{}{
+				"type":        "array",
+				"description": "Headings of the document, when the outline was requested",
+				"items": map[string]interface{}{
+					"type": "object",
+					"properties": map[string]interface{}{
+						"id":     stringSchema("Section ID to read"),
+						"title":  stringSchema(""),
+						"level":  map[string]interface{}{"type": "integer"},
+						"tokens": map[string]interface{}{"type": "integer", "description": "Estimated size"},
+					},
+					"required": []string{"id", "title", "level", "tokens"},
+				},
+			},
+			"redirectedFrom": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]string{"type": "string"},
+				"description": "IDs of retired or overridden documents that redirected here",
+			},
+			"waiver": stringSchema("Reason the guideline is waived in this project; do not apply it"),
+			"related": map[string]interface{}{
+				"type":        "array",
+				"items":       map[string]string{"type": "string"},
+				"description": "IDs of documents linked through related, supersedes or requires",
+			},
+		},
+		"required": []string{"id", "title", "description", "scopes", "keywords", "status", "severity", "source", "path", "content"},
+	}
+}
+
+func stringSchema(description string) map[string]interface{} {
+	schema := map[string]interface{}{"type": "string"}
+	if description != "" {
+		schema["description"] = description
+	}
+	return schema
+}
+
+func stringArraySchema() map[string]interface{} {
+	return map[string]interface{}{
+		"type":  "array",
+		"items": map[string]string{"type": "string"},
+	}
+}
@@ -0,0 +1,132 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// searchRepository returns every document from search
+type searchRepository struct {
+	MockRepository
+}
+
+func (m *searchRepository) Search(query domain.SearchQuery) []domain.SearchResult {
+	var results []domain.SearchResult
+	for _, doc := range m.Documents {
+		results = append(results, domain.SearchResult{Document: doc, Score: 2.5})
+	}
+	return results
+}
+
+// sessionWithProtocol opens a session that negotiated the protocol version
+func sessionWithProtocol(t *testing.T, srv *Server, version string) *session {
+	t.Helper()
+	sess := srv.openSession(nil)
+	id := json.RawMessage("1")
+	params := json.RawMessage(`{"protocolVersion":"` + version + `"}`)
+	if res := srv.handleRequest(context.Background(), sess, request{JSONRPC: "2.0", ID: &id, Method: "initialize", Params: params}); res == nil || res.Error != nil {
+		t.Fatalf("failed to initialize: %+v", res)
+	}
+	return sess
+}
+
+// callTool calls a tool and decodes its structuredContent into v
+func callTool(t *testing.T, srv *Server, name, args string, v interface{}) {
+	t.Helper()
+	params := json.RawMessage(`{"name":"` + name + `","arguments":` + args + `}`)
+	id := json.RawMessage("1")
+	res := srv.handleRequest(context.Background(), sessionWithProtocol(t, srv, "2025-06-18"), request{JSONRPC: "2.0", ID: &id, Method: "tools/call", Params: params})
+	if res == nil || res.Error != nil {
+		t.Fatalf("unexpected response %+v", res)
+	}
+	encoded, err := json.Marshal(res.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var result struct {
+		Content           []struct{ Text string } `json:"content"`
+		StructuredContent json.RawMessage         `json:"structuredContent"`
+	}
+	if err := json.Unmarshal(encoded, &result); err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Content) == 0 || result.Content[0].Text == "" {
+		t.Error("expected the text fallback")
+	}
+	if err := json.Unmarshal(result.StructuredContent, v); err != nil {
+		t.Fatalf("expected structuredContent, got %s: %v", result.StructuredContent, err)
+	}
+}
+
+func TestServer_StructuredContent(t *testing.T) {
+	repo := &searchRepository{MockRepository{Documents: []*domain.Document{
+		{
+			ID: "coding.go.errors", Title: "Error Handling", Description: "Wrap errors", Status: domain.StatusAdopted,
+			Scopes: []string{"coding", "go"}, Keywords: []string{"errors"}, Path: "1:coding/go/errors.md",
+			Source: "https://example.com/guidelines", SourcePath: "coding/go/errors.md",
+			Body: "# Wrapping\n\nWrap errors.\n",
+		},
+		{ID: "coding.go.old-errors", Title: "Old Errors", Status: domain.StatusDeprecated, ReplacedBy: "coding.go.errors"},
+	}}}
+	srv := newServer(repo)
+
+	t.Run("it should return search results as structured content", func(t *testing.T) {
+		var out searchOutput
+		callTool(t, srv, "search_documents", `{"keywords":["errors"],"limit":1}`, &out)
+		want := documentSummary{
+			ID: "coding.go.errors", Title: "Error Handling", Description: "Wrap errors",
+			Scopes: []string{"coding", "go"}, Keywords: []string{"errors"}, Score: 2.5, Status: "adopted",
+			Source: "https://example.com/guidelines", Path: "coding/go/errors.md",
+		}
+		if len(out.Documents) != 1 || !reflect.DeepEqual(out.Documents[0], want) {
+			t.Errorf("expected %+v, got %+v", want, out.Documents)
+		}
+		if out.Total != 2 || out.NextCursor == "" {
+			t.Errorf("expected total 2 with a next page, got %d %q", out.Total, out.NextCursor)
+		}
+	})
+
+	t.Run("it should return the document as structured content", func(t *testing.T) {
+		var out documentOutput
+		callTool(t, srv, "read_document", `{"id":"coding.go.old-errors"}`, &out)
+		if out.ID != "coding.go.errors" || out.Content != "# Wrapping\n\nWrap errors.\n" || out.Severity != "should" {
+			t.Errorf("unexpected document %+v", out)
+		}
+		if !reflect.DeepEqual(out.RedirectedFrom, []string{"coding.go.old-errors"}) {
+			t.Errorf("expected the redirect, got %v", out.RedirectedFrom)
+		}
+	})
+
+	t.Run("it should return the outline as structured content", func(t *testing.T) {
+		var out documentOutput
+		callTool(t, srv, "read_document", `{"id":"coding.go.errors","outline":true}`, &out)
+		if len(out.Outline) != 1 || out.Outline[0].ID != "coding.go.errors#wrapping" || out.Content != "" {
+			t.Errorf("unexpected outline %+v", out)
+		}
+	})
+	t.Run("it should leave out structured results for clients of an older protocol", func(t *testing.T) {
+		sess := sessionWithProtocol(t, srv, "2025-03-26")
+		id := json.RawMessage("2")
+		for _, req := range []request{
+			{JSONRPC: "2.0", ID: &id, Method: "tools/list"},
+			{JSONRPC: "2.0", ID: &id, Method: "tools/call", Params: json.RawMessage(`{"name":"read_document","arguments":{"id":"coding.go.errors"}}`)},
+		} {
+			res := srv.handleRequest(context.Background(), sess, req)
+			if res == nil || res.Error != nil {
+				t.Fatalf("unexpected response %+v", res)
+			}
+			encoded, err := json.Marshal(res.Result)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if strings.Contains(string(encoded), "outputSchema") || strings.Contains(string(encoded), "structuredContent") {
+				t.Errorf("expected no structured results for %s, got %s", req.Method, encoded)
+			}
+		}
+	})
+}
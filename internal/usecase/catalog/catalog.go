@@ -0,0 +1,74 @@
+package catalog
+
+import (
+	"sort"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+type UseCase struct {
+	Repo domain.DocumentRepository
+}
+
+func New(repo domain.DocumentRepository) *UseCase {
+	return &UseCase{Repo: repo}
+}
+
+// ScopeResult is the content of a scope
+type ScopeResult struct {
+	Path      []string           // Scope names as written in the documents
+	Documents []*domain.Document // Documents in the scope and below it, ordered by ID
+	Found     bool
+}
+
+// Documents returns the guidelines clients may browse, ordered by ID.
+// Retired, overridden and waived documents are left out; they stay readable by ID.
+// Prompts are served through MCP prompts instead.
+func (uc *UseCase) Documents() []*domain.Document {
+	var docs []*domain.Document
+	for _, doc := range uc.Repo.GetAll() {
+		if !doc.IsHidden(false) && !doc.IsPrompt() {
+			docs = append(docs, doc)
+		}
+	}
+	sortByID(docs)
+	return docs
+}
+
+// Scopes returns the scope paths that hold at least one browsable document, depth-first
+func (uc *UseCase) Scopes() [][]string {
+	var scopes [][]string
+	var path []string
+	domain.BuildScopeTree(uc.Documents()).Walk(func(node *domain.ScopeNode, depth int) {
+		if depth == 0 {
+			return
+		}
+		path = append(path[:depth-1], node.Name)
+		scopes = append(scopes, append([]string(nil), path...))
+	})
+	return scopes
+}
+
+// Scope returns the browsable documents of a scope path (case-insensitive)
+func (uc *UseCase) Scope(path []string) ScopeResult {
+	node := domain.BuildScopeTree(uc.Documents()).Find(path)
+	if node == nil || len(path) == 0 {
+		return ScopeResult{Path: path}
+	}
+
+	result := ScopeResult{Found: true}
+	for n := node; n.Parent != nil; n = n.Parent {
+		result.Path = append([]string{n.Name}, result.Path...)
+	}
+	node.Walk(func(n *domain.ScopeNode, _ int) {
+		result.Documents = append(result.Documents, n.Documents...)
+	})
+	sortByID(result.Documents)
+	return result
+}
+
+func sortByID(docs []*domain.Document) {
+	sort.Slice(docs, func(a, b int) bool {
+		return docs[a].ID < docs[b].ID
+	})
+}
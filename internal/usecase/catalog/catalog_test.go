@@ -0,0 +1,117 @@
+package catalog
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// MockRepository for testing
+type MockRepository struct {
+	Documents []*domain.Document
+}
+
+func (m *MockRepository) GetAll() []*domain.Document { return m.Documents }
+func (m *MockRepository) GetErrors() []error         { return nil }
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	return nil, false
+}
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                           { return nil }
+
+func newRepository() *MockRepository {
+	return &MockRepository{Documents: []*domain.Document{
+		{ID: "go.testing", Scopes: []string{"coding", "Go", "testing"}, Status: domain.StatusAdopted},
+		{ID: "go.errors", Scopes: []string{"coding", "Go"}, Status: domain.StatusAdopted},
+		{ID: "ts.naming", Scopes: []string{"coding", "typescript"}, Status: domain.StatusAdopted},
+		{ID: "go.logging-v1", Scopes: []string{"legacy"}, Status: domain.StatusDeprecated, ReplacedBy: "go.errors"},
+		{ID: "go.panics", Scopes: []string{"coding", "Go"}, Status: domain.StatusAdopted, Waiver: "legacy code base"},
+		{ID: "readme", Status: domain.StatusAdopted},
+		{ID: "prompts.review", Scopes: []string{"prompts"}, Kind: domain.KindPrompt, Status: domain.StatusAdopted},
+	}}
+}
+
+func ids(docs []*domain.Document) []string {
+	var result []string
+	for _, doc := range docs {
+		result = append(result, doc.ID)
+	}
+	return result
+}
+
+func TestUseCase_Documents(t *testing.T) {
+	got := ids(New(newRepository()).Documents())
+	expected := []string{"go.errors", "go.testing", "readme", "ts.naming"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("it should list browsable documents by ID: expected %v, got %v", expected, got)
+	}
+}
+
+func TestUseCase_Scopes(t *testing.T) {
+	got := New(newRepository()).Scopes()
+	expected := [][]string{
+		{"coding"},
+		{"coding", "Go"},
+		{"coding", "Go", "testing"},
+		{"coding", "typescript"},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("it should list scope paths depth-first: expected %v, got %v", expected, got)
+	}
+}
+
+func TestUseCase_Scope(t *testing.T) {
+	uc := New(newRepository())
+
+	tests := []struct {
+		name     string
+		path     []string
+		found    bool
+		expected []string
+		display  []string
+	}{
+		{
+			name:     "it should include documents of nested scopes",
+			path:     []string{"coding", "go"},
+			found:    true,
+			expected: []string{"go.errors", "go.testing"},
+			display:  []string{"coding", "Go"},
+		},
+		{
+			name:     "it should return a leaf scope",
+			path:     []string{"coding", "go", "testing"},
+			found:    true,
+			expected: []string{"go.testing"},
+			display:  []string{"coding", "Go", "testing"},
+		},
+		{
+			name:  "it should not find scopes that hold only hidden documents",
+			path:  []string{"legacy"},
+			found: false,
+		},
+		{
+			name:  "it should not treat the root as a scope",
+			path:  nil,
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := uc.Scope(tt.path)
+			if result.Found != tt.found {
+				t.Fatalf("expected found %v, got %v", tt.found, result.Found)
+			}
+			if !tt.found {
+				return
+			}
+			if got := ids(result.Documents); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+			if !reflect.DeepEqual(result.Path, tt.display) {
+				t.Errorf("expected path %v, got %v", tt.display, result.Path)
+			}
+		})
+	}
+}
@@ -30,6 +30,7 @@ type UpdateOptions struct {
 	Cwd         string
 	LocalSource string
 	References  []string
+	Namespaces  map[string]string // Reference -> ID prefix (see config.Config.Namespaces)
 	Content     map[string]string // Optional override for testing
 }
 
@@ -186,6 +187,7 @@ func (g *Generator) setupSkillsIndexer(opts UpdateOptions) (*kexfs.Indexer, erro
 	factoryCfg := config.Config{
 		Source:     opts.LocalSource,
 		References: opts.References,
+		Namespaces: opts.Namespaces,
 	}
 	factory := kexfs.NewProviderFactory(factoryCfg, l)
 
@@ -228,7 +230,7 @@ func (g *Generator) loadSkillsDocs(indexer *kexfs.Indexer, keywords []string) []
 	// Search (Exact keyword matching)
 	// We pass 'nil' for scopes because the config provides Keywords that act as scopes/filters
 	// in strict mode (exactScopeMatch=true).
-	foundDocs := indexer.Search(keywords, nil, true)
+	foundDocs := indexer.Search(domain.SearchQuery{Keywords: keywords, ExactScopeMatch: true})
 
 	var loadedDocs []*domain.Document
 	for _, d := range foundDocs {
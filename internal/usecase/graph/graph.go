@@ -0,0 +1,137 @@
+package graph
+
+import (
+	"context"
+	"sort"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+const (
+	DefaultDepth = 1
+	MaxDepth     = 3
+)
+
+type UseCase struct {
+	Repo domain.DocumentRepository
+}
+
+func New(repo domain.DocumentRepository) *UseCase {
+	return &UseCase{Repo: repo}
+}
+
+// Neighbour is a document reached by following relations
+type Neighbour struct {
+	Document *domain.Document
+	Relation domain.Relation // How the neighbour relates to the document it was reached from
+	From     string          // ID of that document
+	Depth    int             // Number of relations followed (1 = direct neighbour)
+}
+
+type Result struct {
+	Document   *domain.Document
+	Neighbours []Neighbour // Breadth-first, each document once
+	Found      bool
+}
+
+// Execute collects the documents within depth relations of the document, in both directions
+// (a document that requires this one shows up as "required by"). Unknown IDs are skipped.
+// A cancelled context stops the traversal and returns its error.
+func (uc *UseCase) Execute(ctx context.Context, id string, depth int) (Result, error) {
+	if depth <= 0 {
+		depth = DefaultDepth
+	}
+	if depth > MaxDepth {
+		depth = MaxDepth
+	}
+
+	// Metadata only: GetAll does not load bodies
+	docs := make(map[string]*domain.Document)
+	for _, doc := range uc.Repo.GetAll() {
+		docs[doc.ID] = doc
+	}
+	root, ok := docs[id]
+	if !ok {
+		return Result{}, nil
+	}
+
+	edges := buildEdges(docs)
+	result := Result{Document: root, Found: true}
+	visited := map[string]struct{}{id: {}}
+	frontier := []string{id}
+
+	for level := 1; level <= depth && len(frontier) > 0; level++ {
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		var next []string
+		for _, from := range frontier {
+			for _, link := range edges[from] {
+				if _, seen := visited[link.ID]; seen {
+					continue
+				}
+				visited[link.ID] = struct{}{}
+				result.Neighbours = append(result.Neighbours, Neighbour{
+					Document: docs[link.ID],
+					Relation: link.Relation,
+					From:     from,
+					Depth:    level,
+				})
+				next = append(next, link.ID)
+			}
+		}
+		frontier = next
+	}
+	return result, nil
+}
+
+// buildEdges returns the links of every document, declared and inverse, in a stable order
+func buildEdges(docs map[string]*domain.Document) map[string][]domain.Link {
+	edges := make(map[string][]domain.Link)
+	seen := make(map[string]map[domain.Link]struct{})
+	add := func(from string, link domain.Link) {
+		if seen[from] == nil {
+			seen[from] = make(map[domain.Link]struct{})
+		}
+		if _, dup := seen[from][link]; dup {
+			return
+		}
+		seen[from][link] = struct{}{}
+		edges[from] = append(edges[from], link)
+	}
+
+	for id, doc := range docs {
+		for _, link := range doc.Links() {
+			if _, ok := docs[link.ID]; !ok || link.ID == id {
+				continue
+			}
+			add(id, link)
+			add(link.ID, domain.Link{Relation: link.Relation.Inverse(), ID: id})
+		}
+	}
+
+	for _, links := range edges {
+		sort.Slice(links, func(a, b int) bool {
+			if links[a].Relation != links[b].Relation {
+				return relationOrder(links[a].Relation) < relationOrder(links[b].Relation)
+			}
+			return links[a].ID < links[b].ID
+		})
+	}
+	return edges
+}
+
+// relationOrder lists prerequisites first and loose relations last
+func relationOrder(r domain.Relation) int {
+	switch r {
+	case domain.RelationRequires:
+		return 0
+	case domain.RelationSupersededBy:
+		return 1
+	case domain.RelationSupersedes:
+		return 2
+	case domain.RelationRequiredBy:
+		return 3
+	}
+	return 4
+}
@@ -0,0 +1,111 @@
+package graph
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// MockRepository for testing
+type MockRepository struct {
+	Documents []*domain.Document
+}
+
+func (m *MockRepository) GetAll() []*domain.Document { return m.Documents }
+func (m *MockRepository) GetErrors() []error         { return nil }
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	return nil, false
+}
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                           { return nil }
+
+func TestUseCase_Execute(t *testing.T) {
+	repo := &MockRepository{Documents: []*domain.Document{
+		{ID: "errors", Title: "Error Handling", Requires: []string{"logging"}, Related: []string{"retries", "missing"}},
+		{ID: "logging", Title: "Logging Conventions"},
+		{ID: "retries", Title: "Retries"},
+		{ID: "logging-v2", Title: "Structured Logging", Supersedes: []string{"logging"}},
+		{ID: "handlers", Title: "HTTP Handlers", Requires: []string{"errors"}},
+	}}
+	uc := New(repo)
+
+	type hop struct {
+		ID       string
+		Relation domain.Relation
+		From     string
+		Depth    int
+	}
+	hops := func(result Result) []hop {
+		var out []hop
+		for _, n := range result.Neighbours {
+			out = append(out, hop{n.Document.ID, n.Relation, n.From, n.Depth})
+		}
+		return out
+	}
+
+	tests := []struct {
+		name     string
+		id       string
+		depth    int
+		expected []hop
+	}{
+		{
+			name:  "it should list direct neighbours in both directions and skip unknown IDs",
+			id:    "errors",
+			depth: 0,
+			expected: []hop{
+				{"logging", domain.RelationRequires, "errors", 1},
+				{"handlers", domain.RelationRequiredBy, "errors", 1},
+				{"retries", domain.RelationRelated, "errors", 1},
+			},
+		},
+		{
+			name:  "it should follow relations up to the requested depth",
+			id:    "errors",
+			depth: 2,
+			expected: []hop{
+				{"logging", domain.RelationRequires, "errors", 1},
+				{"handlers", domain.RelationRequiredBy, "errors", 1},
+				{"retries", domain.RelationRelated, "errors", 1},
+				{"logging-v2", domain.RelationSupersededBy, "logging", 2},
+			},
+		},
+		{
+			name:     "it should report related documents symmetrically",
+			id:       "retries",
+			depth:    1,
+			expected: []hop{{"errors", domain.RelationRelated, "retries", 1}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.Execute(context.Background(), tt.id, tt.depth)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Found {
+				t.Fatal("expected document to be found")
+			}
+			if got := hops(result); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	t.Run("it should report unknown documents", func(t *testing.T) {
+		if result, _ := uc.Execute(context.Background(), "nope", 1); result.Found {
+			t.Error("expected not found")
+		}
+	})
+
+	t.Run("it should stop when the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := uc.Execute(ctx, "errors", 2); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,84 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"text/template"
+
+	"github.com/mew-ton/kex/internal/domain"
+	"github.com/mew-ton/kex/internal/usecase/retrieve"
+)
+
+type UseCase struct {
+	Repo       domain.DocumentRepository
+	RetrieveUC *retrieve.UseCase // Resolves documents embedded with {{document "id"}}
+}
+
+func New(repo domain.DocumentRepository) *UseCase {
+	return &UseCase{Repo: repo, RetrieveUC: retrieve.New(repo)}
+}
+
+type Result struct {
+	Document *domain.Document
+	Text     string // Rendered body
+	Found    bool
+}
+
+// List returns the prompts clients may use, ordered by ID.
+// Retired, overridden and waived prompts are left out.
+func (uc *UseCase) List() []*domain.Document {
+	var prompts []*domain.Document
+	for _, doc := range uc.Repo.GetAll() {
+		if doc.IsPrompt() && !doc.IsHidden(false) {
+			prompts = append(prompts, doc)
+		}
+	}
+	sort.Slice(prompts, func(a, b int) bool {
+		return prompts[a].ID < prompts[b].ID
+	})
+	return prompts
+}
+
+// Get renders the prompt with the argument values.
+// An overridden prompt renders its local variant. Invalid arguments and templates return an error,
+// and so does a cancelled context.
+func (uc *UseCase) Get(ctx context.Context, name string, args map[string]string) (Result, error) {
+	found, err := uc.RetrieveUC.Execute(ctx, name)
+	if err != nil {
+		return Result{}, err
+	}
+	if !found.Found || found.Slug != "" || !found.Document.IsPrompt() || found.Document.IsHidden(false) {
+		return Result{}, nil
+	}
+
+	text, err := found.Document.RenderPrompt(args, template.FuncMap{
+		"document": func(id string) (string, error) { return uc.document(ctx, id) },
+	})
+	if err != nil {
+		return Result{Document: found.Document, Found: true}, fmt.Errorf("%s: %w", found.Document.ID, err)
+	}
+	return Result{Document: found.Document, Text: text, Found: true}, nil
+}
+
+// document returns the body (or section) of a guideline for {{document "id"}}.
+// Replacements and local variants are followed; a waived guideline renders as nothing.
+func (uc *UseCase) document(ctx context.Context, id string) (string, error) {
+	result, err := uc.RetrieveUC.Execute(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if !result.Found {
+		return "", fmt.Errorf("document %q not found", id)
+	}
+	if result.Slug != "" && result.Section == nil {
+		return "", fmt.Errorf("section %q of document %q not found", result.Slug, result.Document.ID)
+	}
+	if result.Document.Waiver != "" {
+		return "", nil
+	}
+	if result.Section != nil {
+		return result.Section.Content(result.Document.Body), nil
+	}
+	return result.Document.Body, nil
+}
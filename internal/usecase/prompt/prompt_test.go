@@ -0,0 +1,140 @@
+package prompt
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// MockRepository for testing
+type MockRepository struct {
+	Documents []*domain.Document
+}
+
+func (m *MockRepository) GetAll() []*domain.Document { return m.Documents }
+func (m *MockRepository) GetErrors() []error         { return nil }
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	for _, doc := range m.Documents {
+		if doc.ID == id {
+			return doc, true
+		}
+	}
+	return nil, false
+}
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                           { return nil }
+
+func newRepository() *MockRepository {
+	return &MockRepository{Documents: []*domain.Document{
+		{
+			ID:     "prompts.review",
+			Kind:   domain.KindPrompt,
+			Status: domain.StatusAdopted,
+			Arguments: []domain.PromptArgument{
+				{Name: "file", Required: true},
+				{Name: "focus"},
+			},
+			Body: "Review {{.file}}{{if .focus}} with a focus on {{.focus}}{{end}}.\n\n{{document \"errors#wrapping\"}}",
+		},
+		{
+			ID:     "prompts.plan",
+			Kind:   domain.KindPrompt,
+			Status: domain.StatusAdopted,
+			Body:   "Plan the change.\n{{document \"panics\"}}",
+		},
+		{ID: "prompts.old", Kind: domain.KindPrompt, Status: domain.StatusArchived, Body: "Old."},
+		{ID: "prompts.missing", Kind: domain.KindPrompt, Status: domain.StatusAdopted, Body: "{{document \"nope\"}}"},
+		{ID: "errors", Status: domain.StatusAdopted, Body: "## Summary\n\nWrap.\n\n## Wrapping\n\nUse %w.\n"},
+		{ID: "panics", Status: domain.StatusAdopted, Body: "Do not panic.", Waiver: "legacy"},
+	}}
+}
+
+func TestUseCase_List(t *testing.T) {
+	var ids []string
+	for _, doc := range New(newRepository()).List() {
+		ids = append(ids, doc.ID)
+	}
+	expected := []string{"prompts.missing", "prompts.plan", "prompts.review"}
+	if !reflect.DeepEqual(ids, expected) {
+		t.Errorf("it should list usable prompts by ID: expected %v, got %v", expected, ids)
+	}
+}
+
+func TestUseCase_Get(t *testing.T) {
+	uc := New(newRepository())
+
+	tests := []struct {
+		name     string
+		prompt   string
+		args     map[string]string
+		found    bool
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "it should render arguments and embedded sections",
+			prompt:   "prompts.review",
+			args:     map[string]string{"file": "main.go", "focus": "errors"},
+			found:    true,
+			expected: "Review main.go with a focus on errors.\n\n## Wrapping\n\nUse %w.\n",
+		},
+		{
+			name:     "it should leave optional arguments empty",
+			prompt:   "prompts.review",
+			args:     map[string]string{"file": "main.go"},
+			found:    true,
+			expected: "Review main.go.\n\n## Wrapping\n\nUse %w.\n",
+		},
+		{
+			name:     "it should render waived guidelines as nothing",
+			prompt:   "prompts.plan",
+			found:    true,
+			expected: "Plan the change.\n",
+		},
+		{
+			name:    "it should reject a missing required argument",
+			prompt:  "prompts.review",
+			args:    map[string]string{"focus": "errors"},
+			found:   true,
+			wantErr: true,
+		},
+		{
+			name:    "it should reject unknown arguments",
+			prompt:  "prompts.review",
+			args:    map[string]string{"file": "main.go", "path": "x"},
+			found:   true,
+			wantErr: true,
+		},
+		{
+			name:    "it should fail when an embedded document is unknown",
+			prompt:  "prompts.missing",
+			found:   true,
+			wantErr: true,
+		},
+		{
+			name:   "it should not find retired prompts",
+			prompt: "prompts.old",
+		},
+		{
+			name:   "it should not find guidelines",
+			prompt: "errors",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.Get(context.Background(), tt.prompt, tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if result.Found != tt.found {
+				t.Fatalf("expected found %v, got %v", tt.found, result.Found)
+			}
+			if !tt.wantErr && result.Text != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result.Text)
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package retrieve
 
 import (
+	"context"
+
 	"github.com/mew-ton/kex/internal/domain"
 )
 
+// MaxRedirects bounds how many replacements are followed
+const MaxRedirects = 5
+
 type UseCase struct {
 	Repo domain.DocumentRepository
 }
@@ -15,12 +20,68 @@ func New(repo domain.DocumentRepository) *UseCase {
 type Result struct {
 	Document *domain.Document
 	Found    bool
+	// Redirects lists the documents that led to Document, in order
+	// (retired ones through replacedBy, overridden ones through their local variant)
+	Redirects []*domain.Document
+	// Slug is the requested section ("" = whole document)
+	Slug string
+	// Section is the requested section of Document (nil if the slug matches no heading)
+	Section *domain.Section
 }
 
-func (uc *UseCase) Execute(id string) Result {
-	doc, ok := uc.Repo.GetByID(id)
-	return Result{
-		Document: doc,
-		Found:    ok,
+// Execute returns the document, following replacedBy from retired documents to their replacement
+// and overridden documents to the local variant served instead.
+// A retired document without a known replacement is returned as is.
+// The ID may address a section (e.g. coding.go.errors#wrapping).
+// The context cancels fetching bodies; a cancelled context returns its error.
+func (uc *UseCase) Execute(ctx context.Context, id string) (Result, error) {
+	id, slug := domain.SplitSectionID(id)
+	doc, ok := domain.GetByIDContext(ctx, uc.Repo, id)
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	if !ok {
+		return Result{Document: doc, Slug: slug}, nil
+	}
+
+	result := Result{Document: doc, Found: true, Slug: slug}
+	visited := map[string]struct{}{doc.ID: {}}
+	for len(result.Redirects) < MaxRedirects {
+		target := redirectTarget(doc)
+		if target == "" {
+			break
+		}
+		if _, loop := visited[target]; loop {
+			break
+		}
+		next, ok := domain.GetByIDContext(ctx, uc.Repo, target)
+		if err := ctx.Err(); err != nil {
+			return Result{}, err
+		}
+		if !ok {
+			break
+		}
+		visited[next.ID] = struct{}{}
+		result.Redirects = append(result.Redirects, doc)
+		result.Document = next
+		doc = next
+	}
+
+	if slug != "" {
+		if section, ok := domain.FindSection(doc.Sections(), slug); ok {
+			result.Section = &section
+		}
+	}
+	return result, nil
+}
+
+// redirectTarget returns the ID of the document to serve instead ("" = serve this one)
+func redirectTarget(doc *domain.Document) string {
+	if doc.OverriddenBy != "" {
+		return doc.OverriddenBy
+	}
+	if doc.Status.IsRetired() {
+		return doc.ReplacedBy
 	}
+	return ""
 }
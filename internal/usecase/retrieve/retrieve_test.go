@@ -0,0 +1,190 @@
+package retrieve
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// MockRepository for testing
+type MockRepository struct {
+	Documents []*domain.Document
+}
+
+func (m *MockRepository) GetAll() []*domain.Document { return m.Documents }
+func (m *MockRepository) GetErrors() []error         { return nil }
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	for _, doc := range m.Documents {
+		if doc.ID == id {
+			return doc, true
+		}
+	}
+	return nil, false
+}
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                           { return nil }
+
+func TestUseCase_Execute(t *testing.T) {
+	repo := &MockRepository{Documents: []*domain.Document{
+		{ID: "logging", Status: domain.StatusAdopted},
+		{ID: "logging-v2", Status: domain.StatusDeprecated, ReplacedBy: "logging"},
+		{ID: "logging-v1", Status: domain.StatusArchived, ReplacedBy: "logging-v2"},
+		{ID: "orphan", Status: domain.StatusDeprecated, ReplacedBy: "missing"},
+		{ID: "loop-a", Status: domain.StatusDeprecated, ReplacedBy: "loop-b"},
+		{ID: "loop-b", Status: domain.StatusDeprecated, ReplacedBy: "loop-a"},
+		{ID: "pointer", Status: domain.StatusAdopted, ReplacedBy: "logging"},
+	}}
+	uc := New(repo)
+
+	tests := []struct {
+		name      string
+		id        string
+		found     bool
+		expected  string
+		redirects []string
+	}{
+		{
+			name:     "it should return an adopted document as is",
+			id:       "logging",
+			found:    true,
+			expected: "logging",
+		},
+		{
+			name:      "it should follow replacedBy through retired documents",
+			id:        "logging-v1",
+			found:     true,
+			expected:  "logging",
+			redirects: []string{"logging-v1", "logging-v2"},
+		},
+		{
+			name:     "it should return a retired document whose replacement is unknown",
+			id:       "orphan",
+			found:    true,
+			expected: "orphan",
+		},
+		{
+			name:      "it should stop at a replacedBy loop",
+			id:        "loop-a",
+			found:     true,
+			expected:  "loop-b",
+			redirects: []string{"loop-a"},
+		},
+		{
+			name:     "it should ignore replacedBy on documents that are not retired",
+			id:       "pointer",
+			found:    true,
+			expected: "pointer",
+		},
+		{
+			name:  "it should report unknown IDs as not found",
+			id:    "missing",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.Execute(context.Background(), tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.Found != tt.found {
+				t.Fatalf("expected found %v, got %v", tt.found, result.Found)
+			}
+			if !tt.found {
+				return
+			}
+			if result.Document.ID != tt.expected {
+				t.Errorf("expected %s, got %s", tt.expected, result.Document.ID)
+			}
+			var redirects []string
+			for _, doc := range result.Redirects {
+				redirects = append(redirects, doc.ID)
+			}
+			if !reflect.DeepEqual(redirects, tt.redirects) {
+				t.Errorf("expected redirects %v, got %v", tt.redirects, redirects)
+			}
+		})
+	}
+}
+
+func TestUseCase_Execute_Section(t *testing.T) {
+	body := "## Summary\n\nWrap errors.\n\n## Error Wrapping (Go 1.13+)\n\nUse `%w`.\n\n```md\n## Not a heading\n```\n\n### Examples\n\nSee below.\n\n## Examples\n\nMore.\n"
+	repo := &MockRepository{Documents: []*domain.Document{
+		{ID: "errors", Status: domain.StatusAdopted, Body: body},
+		{ID: "old-errors", Status: domain.StatusDeprecated, ReplacedBy: "errors"},
+	}}
+	uc := New(repo)
+
+	tests := []struct {
+		name    string
+		id      string
+		found   bool
+		content string
+	}{
+		{
+			name:    "it should return a section with its subsections",
+			id:      "errors#error-wrapping-go-113",
+			found:   true,
+			content: "## Error Wrapping (Go 1.13+)\n\nUse `%w`.\n\n```md\n## Not a heading\n```\n\n### Examples\n\nSee below.\n\n",
+		},
+		{
+			name:    "it should suffix repeated headings",
+			id:      "errors#examples-1",
+			found:   true,
+			content: "## Examples\n\nMore.\n",
+		},
+		{
+			name:    "it should keep the section when following a redirect",
+			id:      "old-errors#summary",
+			found:   true,
+			content: "## Summary\n\nWrap errors.\n\n",
+		},
+		{
+			name:  "it should not find headings inside code fences",
+			id:    "errors#not-a-heading",
+			found: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.Execute(context.Background(), tt.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !result.Found {
+				t.Fatal("expected the document to be found")
+			}
+			if (result.Section != nil) != tt.found {
+				t.Fatalf("expected section found %v, got %v", tt.found, result.Section)
+			}
+			if !tt.found {
+				return
+			}
+			if got := result.Section.Content(result.Document.Body); got != tt.content {
+				t.Errorf("expected content %q, got %q", tt.content, got)
+			}
+		})
+	}
+}
+
+func TestUseCase_Execute_Override(t *testing.T) {
+	repo := &MockRepository{Documents: []*domain.Document{
+		{ID: "org:naming", Status: domain.StatusAdopted, OverriddenBy: "naming"},
+		{ID: "naming", Status: domain.StatusAdopted, Overrides: "org:naming"},
+	}}
+
+	result, err := New(repo).Execute(context.Background(), "org:naming")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Found || result.Document.ID != "naming" {
+		t.Fatalf("expected the local variant, got %v", result.Document)
+	}
+	if len(result.Redirects) != 1 || result.Redirects[0].ID != "org:naming" {
+		t.Errorf("expected a redirect from org:naming, got %v", result.Redirects)
+	}
+}
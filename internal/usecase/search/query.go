@@ -0,0 +1,231 @@
+package search
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// supportedFields lists the fields usable as "field:value" filters
+var supportedFields = map[string]struct{}{
+	domain.FieldTitle:       {},
+	domain.FieldDescription: {},
+	domain.FieldKeyword:     {},
+	domain.FieldBody:        {},
+	domain.FieldScope:       {},
+	domain.FieldStatus:      {},
+	domain.FieldSeverity:    {},
+	domain.FieldID:          {},
+}
+
+// ParseQuery parses the search_documents query syntax into an AST.
+//
+//	error handling scope:go -legacy status:draft keyword:testing
+//
+// Adjacent clauses are combined with AND; "OR" (uppercase) combines alternatives,
+// "-" or "NOT" negates a clause, parentheses group clauses and double quotes
+// keep a multi-word value together (e.g. keyword:"error handling").
+func ParseQuery(input string) (domain.QueryNode, error) {
+	tokens, err := lexQuery(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+
+	p := &queryParser{tokens: tokens}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if !p.done() {
+		return nil, fmt.Errorf("unexpected %q", p.peek().text)
+	}
+	return node, nil
+}
+
+type queryTokenKind int
+
+const (
+	tokenWord queryTokenKind = iota
+	tokenPhrase
+	tokenLParen
+	tokenRParen
+	tokenNegate
+)
+
+type queryToken struct {
+	kind queryTokenKind
+	text string
+}
+
+// lexQuery splits the input into words, quoted phrases, parentheses and negations
+func lexQuery(input string) ([]queryToken, error) {
+	var tokens []queryToken
+	runes := []rune(input)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '(':
+			tokens = append(tokens, queryToken{kind: tokenLParen, text: "("})
+			i++
+		case r == ')':
+			tokens = append(tokens, queryToken{kind: tokenRParen, text: ")"})
+			i++
+		case r == '-' && i+1 < len(runes) && !unicode.IsSpace(runes[i+1]):
+			tokens = append(tokens, queryToken{kind: tokenNegate, text: "-"})
+			i++
+		case r == '"':
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			if end >= len(runes) {
+				return nil, fmt.Errorf("unterminated quote")
+			}
+			tokens = append(tokens, queryToken{kind: tokenPhrase, text: string(runes[i+1 : end])})
+			i = end + 1
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) && runes[i] != '(' && runes[i] != ')' {
+				// A quote after "field:" starts the value (e.g. keyword:"error handling")
+				if runes[i] == '"' && i > start && runes[i-1] == ':' {
+					end := i + 1
+					for end < len(runes) && runes[end] != '"' {
+						end++
+					}
+					if end >= len(runes) {
+						return nil, fmt.Errorf("unterminated quote")
+					}
+					i = end + 1
+					break
+				}
+				i++
+			}
+			tokens = append(tokens, queryToken{kind: tokenWord, text: string(runes[start:i])})
+		}
+	}
+	return tokens, nil
+}
+
+type queryParser struct {
+	tokens []queryToken
+	pos    int
+}
+
+func (p *queryParser) done() bool { return p.pos >= len(p.tokens) }
+
+func (p *queryParser) peek() queryToken { return p.tokens[p.pos] }
+
+func (p *queryParser) isOperator(op string) bool {
+	return !p.done() && p.peek().kind == tokenWord && p.peek().text == op
+}
+
+// parseOr: and ("OR" and)*
+func (p *queryParser) parseOr() (domain.QueryNode, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []domain.QueryNode{first}
+	for p.isOperator("OR") {
+		p.pos++
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return domain.OrNode{Children: children}, nil
+}
+
+// parseAnd: unary (["AND"] unary)*
+func (p *queryParser) parseAnd() (domain.QueryNode, error) {
+	var children []domain.QueryNode
+	for !p.done() && p.peek().kind != tokenRParen && !p.isOperator("OR") {
+		if p.isOperator("AND") {
+			p.pos++
+			continue
+		}
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, node)
+	}
+	switch len(children) {
+	case 0:
+		return nil, fmt.Errorf("expected a search term")
+	case 1:
+		return children[0], nil
+	}
+	return domain.AndNode{Children: children}, nil
+}
+
+// parseUnary: ("-" | "NOT") unary | primary
+func (p *queryParser) parseUnary() (domain.QueryNode, error) {
+	if !p.done() && (p.peek().kind == tokenNegate || p.isOperator("NOT")) {
+		p.pos++
+		if p.done() {
+			return nil, fmt.Errorf("expected a term after negation")
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return domain.NotNode{Child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+// parsePrimary: "(" or ")" | field:value | value
+func (p *queryParser) parsePrimary() (domain.QueryNode, error) {
+	tok := p.peek()
+	p.pos++
+
+	switch tok.kind {
+	case tokenLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.done() || p.peek().kind != tokenRParen {
+			return nil, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case tokenPhrase:
+		return domain.TermNode{Field: domain.FieldAny, Value: tok.text}, nil
+	case tokenWord:
+		return parseTerm(tok.text)
+	}
+	return nil, fmt.Errorf("unexpected %q", tok.text)
+}
+
+// parseTerm splits "field:value" (value may be quoted); words without a known field are plain terms
+func parseTerm(text string) (domain.QueryNode, error) {
+	field, value, found := strings.Cut(text, ":")
+	if !found {
+		return domain.TermNode{Field: domain.FieldAny, Value: text}, nil
+	}
+
+	field = strings.ToLower(field)
+	if _, ok := supportedFields[field]; !ok {
+		return nil, fmt.Errorf("unknown field %q", field)
+	}
+
+	value = strings.Trim(value, `"`)
+	if value == "" {
+		return nil, fmt.Errorf("missing value for field %q", field)
+	}
+	return domain.TermNode{Field: field, Value: value}, nil
+}
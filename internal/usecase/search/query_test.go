@@ -0,0 +1,91 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+func TestParseQuery(t *testing.T) {
+	term := func(field, value string) domain.TermNode {
+		return domain.TermNode{Field: field, Value: value}
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    domain.QueryNode
+		wantErr bool
+	}{
+		{
+			name:  "it should parse a single term",
+			input: "testing",
+			want:  term("", "testing"),
+		},
+		{
+			name:  "it should parse a severity filter",
+			input: "severity:must",
+			want:  term("severity", "must"),
+		},
+		{
+			name:  "it should combine adjacent clauses with AND",
+			input: "error handling scope:go -legacy status:draft keyword:testing",
+			want: domain.AndNode{Children: []domain.QueryNode{
+				term("", "error"),
+				term("", "handling"),
+				term("scope", "go"),
+				domain.NotNode{Child: term("", "legacy")},
+				term("status", "draft"),
+				term("keyword", "testing"),
+			}},
+		},
+		{
+			name:  "it should bind AND tighter than OR",
+			input: "go testing OR typescript",
+			want: domain.OrNode{Children: []domain.QueryNode{
+				domain.AndNode{Children: []domain.QueryNode{term("", "go"), term("", "testing")}},
+				term("", "typescript"),
+			}},
+		},
+		{
+			name:  "it should group with parentheses and negate with NOT",
+			input: "NOT (scope:go OR scope:rust) AND logging",
+			want: domain.AndNode{Children: []domain.QueryNode{
+				domain.NotNode{Child: domain.OrNode{Children: []domain.QueryNode{term("scope", "go"), term("scope", "rust")}}},
+				term("", "logging"),
+			}},
+		},
+		{
+			name:  "it should keep quoted values together",
+			input: `"error handling" keyword:"unit test"`,
+			want: domain.AndNode{Children: []domain.QueryNode{
+				term("", "error handling"),
+				term("keyword", "unit test"),
+			}},
+		},
+		{
+			name:  "it should keep hyphens inside words",
+			input: "user-guide",
+			want:  term("", "user-guide"),
+		},
+		{name: "it should reject unknown fields", input: "author:me", wantErr: true},
+		{name: "it should reject empty field values", input: "scope:", wantErr: true},
+		{name: "it should reject unbalanced parentheses", input: "(go OR rust", wantErr: true},
+		{name: "it should reject unterminated quotes", input: `"error handling`, wantErr: true},
+		{name: "it should reject dangling operators", input: "go OR", wantErr: true},
+		{name: "it should reject empty queries", input: "  ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseQuery(tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseQuery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseQuery() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package search
+
+import (
+	"fmt"
+
+	"github.com/mew-ton/kex/internal/domain"
+)
+
+// ScopeRule assigns scopes to file paths matching a glob pattern (see domain.MatchPathPattern)
+type ScopeRule struct {
+	Pattern string
+	Scopes  []string
+}
+
+// DefaultScopeRules are applied after any configured rules.
+// Code changes often imply version control operations, so "vcs" is always included.
+var DefaultScopeRules = []ScopeRule{
+	{Pattern: "*.{ts,tsx,js,jsx}", Scopes: []string{"coding", "typescript", "javascript", "frontend", "vcs"}},
+	{Pattern: "*.go", Scopes: []string{"coding", "go", "backend", "vcs"}},
+	{Pattern: "*.py", Scopes: []string{"coding", "python", "backend", "vcs"}},
+	{Pattern: "*.rs", Scopes: []string{"coding", "rust", "backend", "vcs"}},
+	{Pattern: "*.{tf,tfvars}", Scopes: []string{"coding", "terraform", "infrastructure", "vcs"}},
+	{Pattern: "*.sql", Scopes: []string{"coding", "sql", "database", "vcs"}},
+	{Pattern: "*.{md,txt}", Scopes: []string{"documentation", "vcs"}},
+	{Pattern: "**", Scopes: []string{"coding", "vcs"}},
+}
+
+// ScopeMapper derives search scopes from the file the caller is working on
+type ScopeMapper struct {
+	rules []ScopeRule
+}
+
+// NewScopeMapper checks the given rules and appends DefaultScopeRules (first match wins)
+func NewScopeMapper(rules []ScopeRule) (*ScopeMapper, error) {
+	for _, rule := range rules {
+		if err := domain.ValidatePathPattern(rule.Pattern); err != nil {
+			return nil, fmt.Errorf("invalid scope mapping pattern %q: %w", rule.Pattern, err)
+		}
+	}
+	all := make([]ScopeRule, 0, len(rules)+len(DefaultScopeRules))
+	all = append(all, rules...)
+	all = append(all, DefaultScopeRules...)
+	return &ScopeMapper{rules: all}, nil
+}
+
+// Scopes returns the scopes of the first rule matching the path (nil for an empty path)
+func (m *ScopeMapper) Scopes(filePath string) []string {
+	if filePath == "" {
+		return nil
+	}
+	for _, rule := range m.rules {
+		if domain.MatchPathPattern(rule.Pattern, filePath) {
+			return rule.Scopes
+		}
+	}
+	return nil
+}
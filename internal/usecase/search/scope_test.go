@@ -0,0 +1,90 @@
+package search
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScopeMapper_Scopes(t *testing.T) {
+	mapper, err := NewScopeMapper([]ScopeRule{
+		{Pattern: "services/payments/**", Scopes: []string{"coding", "go", "payments"}},
+		{Pattern: "/infra/*.yaml", Scopes: []string{"infrastructure"}},
+		{Pattern: "**/migrations/*.sql", Scopes: []string{"database", "migrations"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		filePath string
+		expected []string
+	}{
+		{
+			name:     "it should return no scopes for an empty path",
+			filePath: "",
+			expected: nil,
+		},
+		{
+			name:     "it should match a configured directory pattern",
+			filePath: "services/payments/api/handler.go",
+			expected: []string{"coding", "go", "payments"},
+		},
+		{
+			name:     "it should match a directory pattern inside an absolute path",
+			filePath: "/home/me/repo/services/payments/handler.go",
+			expected: []string{"coding", "go", "payments"},
+		},
+		{
+			name:     "it should anchor patterns starting with a slash",
+			filePath: "infra/cluster.yaml",
+			expected: []string{"infrastructure"},
+		},
+		{
+			name:     "it should not match anchored patterns deeper in the path",
+			filePath: "app/infra/cluster.yaml",
+			expected: []string{"coding", "vcs"},
+		},
+		{
+			name:     "it should let ** match any number of directories",
+			filePath: "db/migrations/001_init.sql",
+			expected: []string{"database", "migrations"},
+		},
+		{
+			name:     "it should fall back to the default rules",
+			filePath: "services/orders/main.go",
+			expected: []string{"coding", "go", "backend", "vcs"},
+		},
+		{
+			name:     "it should map python files by default",
+			filePath: "tools/gen.py",
+			expected: []string{"coding", "python", "backend", "vcs"},
+		},
+		{
+			name:     "it should map terraform files by default",
+			filePath: "deploy/main.tf",
+			expected: []string{"coding", "terraform", "infrastructure", "vcs"},
+		},
+		{
+			name:     "it should accept Windows separators",
+			filePath: `services\payments\handler.go`,
+			expected: []string{"coding", "go", "payments"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapper.Scopes(tt.filePath); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Scopes(%q) = %v, want %v", tt.filePath, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewScopeMapper_InvalidPattern(t *testing.T) {
+	for _, pattern := range []string{"", "src/[a-"} {
+		if _, err := NewScopeMapper([]ScopeRule{{Pattern: pattern, Scopes: []string{"x"}}}); err == nil {
+			t.Errorf("expected error for pattern %q", pattern)
+		}
+	}
+}
@@ -1,54 +1,153 @@
 package search
 
 import (
-	"path/filepath"
+	"context"
+	"fmt"
+	"strings"
 
 	"github.com/mew-ton/kex/internal/domain"
 )
 
 type UseCase struct {
-	Repo domain.DocumentRepository
+	Repo   domain.DocumentRepository
+	Scopes *ScopeMapper // Derives scopes from Request.FilePath
 }
 
+var defaultScopeMapper, _ = NewScopeMapper(nil)
+
 func New(repo domain.DocumentRepository) *UseCase {
-	return &UseCase{Repo: repo}
+	return &UseCase{Repo: repo, Scopes: defaultScopeMapper}
 }
 
+// Request holds the parameters of a search
+type Request struct {
+	Keywords        []string
+	Query           string // Query expression (see ParseQuery); combined with Keywords when both are set
+	FilePath        string // File the caller is working on (used for scope filtering)
+	ExactScopeMatch bool
+
+	// Term matching overrides (nil = configured default)
+	Prefix *bool
+	Fuzzy  *bool
+
+	// Paging
+	Limit    int     // Maximum number of documents to return (0 = DefaultLimit)
+	Offset   int     // Number of ranked documents to skip
+	Cursor   string  // NextCursor of a previous result (overrides Offset)
+	MinScore float64 // Documents scoring below this are dropped
+}
+
+// Paging bounds
+const (
+	DefaultLimit = 20
+	MaxLimit     = 100
+)
+
 type Result struct {
-	Documents []*domain.Document
-	Message   string
+	Documents  []domain.SearchResult // Ordered by descending score
+	Total      int                   // Number of matching documents before paging
+	Offset     int                   // Rank of the first returned document (0-based)
+	NextCursor string                // Cursor for the next page ("" = last page)
+	Message    string
 }
 
-func (uc *UseCase) Execute(keywords []string, filePath string, exactScopeMatch bool) Result {
-	scopes := deriveScopes(filePath)
-	docs := uc.Repo.Search(keywords, scopes, exactScopeMatch)
+// Execute runs the search. It returns ctx.Err() once the context is cancelled.
+func (uc *UseCase) Execute(ctx context.Context, req Request) (Result, error) {
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+	expr, err := buildExpr(req)
+	if err != nil {
+		return Result{}, fmt.Errorf("invalid query: %w", err)
+	}
 
-	return Result{
-		Documents: docs,
+	offset := req.Offset
+	if req.Cursor != "" {
+		if offset, err = decodeCursor(req.Cursor); err != nil {
+			return Result{}, err
+		}
 	}
+	if offset < 0 {
+		return Result{}, fmt.Errorf("invalid offset: %d", offset)
+	}
+
+	scopes := uc.Scopes.Scopes(req.FilePath)
+	docs := domain.SearchContext(ctx, uc.Repo, domain.SearchQuery{
+		Keywords:        req.Keywords,
+		Scopes:          scopes,
+		FilePath:        req.FilePath,
+		ExactScopeMatch: req.ExactScopeMatch,
+		Expr:            expr,
+		Prefix:          req.Prefix,
+		Fuzzy:           req.Fuzzy,
+	})
+	if err := ctx.Err(); err != nil {
+		return Result{}, err
+	}
+
+	docs = filterByScore(docs, req.MinScore)
+	return paginate(docs, offset, req.Limit), nil
 }
 
-func deriveScopes(path string) []string {
-	if path == "" {
-		return nil
+// filterByScore drops documents scoring below minScore (results are ordered by score)
+func filterByScore(docs []domain.SearchResult, minScore float64) []domain.SearchResult {
+	if minScore <= 0 {
+		return docs
+	}
+	for n, doc := range docs {
+		if doc.Score < minScore {
+			return docs[:n]
+		}
 	}
-	ext := filepath.Ext(path)
-	switch ext {
-	// TypeScript / JavaScript
-	case ".ts", ".tsx", ".js", ".jsx":
-		// Implicitly include 'vcs' because code changes often imply version control ops
-		return []string{"coding", "typescript", "javascript", "frontend", "vcs"}
+	return docs
+}
 
-	// Go
-	case ".go":
-		return []string{"coding", "go", "backend", "vcs"}
+// paginate returns one page of the ranked documents
+func paginate(docs []domain.SearchResult, offset, limit int) Result {
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	if limit > MaxLimit {
+		limit = MaxLimit
+	}
 
-	// Markdown / Documentation
-	case ".md", ".txt":
-		return []string{"documentation", "vcs"} // text files also version controlled
+	result := Result{Total: len(docs), Offset: offset}
+	if offset >= len(docs) {
+		return result
+	}
+
+	end := offset + limit
+	if end < len(docs) {
+		result.NextCursor = encodeCursor(end)
+	} else {
+		end = len(docs)
+	}
+	result.Documents = docs[offset:end]
+	return result
+}
 
-	default:
-		// Default for unknown code files
-		return []string{"coding", "vcs"}
+// buildExpr parses the query; keywords given alongside it must match at least one
+// (as scopes when ExactScopeMatch is set). Without a query, nil keeps keyword search.
+func buildExpr(req Request) (domain.QueryNode, error) {
+	if strings.TrimSpace(req.Query) == "" {
+		return nil, nil
+	}
+
+	expr, err := ParseQuery(req.Query)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Keywords) == 0 {
+		return expr, nil
+	}
+
+	field := domain.FieldAny
+	if req.ExactScopeMatch {
+		field = domain.FieldScope
+	}
+	anyKeyword := domain.OrNode{}
+	for _, k := range req.Keywords {
+		anyKeyword.Children = append(anyKeyword.Children, domain.TermNode{Field: field, Value: k})
 	}
+	return domain.AndNode{Children: []domain.QueryNode{expr, anyKeyword}}, nil
 }
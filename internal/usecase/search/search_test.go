@@ -1,6 +1,7 @@
 package search
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -9,13 +10,13 @@ import (
 
 // MockRepository for testing
 type MockRepository struct {
-	SearchFunc  func(keywords []string, scopes []string, exactScopeMatch bool) []*domain.Document
+	SearchFunc  func(query domain.SearchQuery) []domain.SearchResult
 	GetByIDFunc func(id string) (*domain.Document, bool)
 }
 
-func (m *MockRepository) Search(keywords []string, scopes []string, exactScopeMatch bool) []*domain.Document {
+func (m *MockRepository) Search(query domain.SearchQuery) []domain.SearchResult {
 	if m.SearchFunc != nil {
-		return m.SearchFunc(keywords, scopes, exactScopeMatch)
+		return m.SearchFunc(query)
 	}
 	return nil
 }
@@ -67,17 +68,20 @@ func TestUseCase_Execute(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := &MockRepository{
-				SearchFunc: func(keywords []string, scopes []string, exactScopeMatch bool) []*domain.Document {
+				SearchFunc: func(query domain.SearchQuery) []domain.SearchResult {
 					// Verify that the correct scopes are passed to the repository
-					if !reflect.DeepEqual(scopes, tt.expectedScopes) {
-						t.Errorf("Execute() passed scopes = %v, want %v", scopes, tt.expectedScopes)
+					if !reflect.DeepEqual(query.Scopes, tt.expectedScopes) {
+						t.Errorf("Execute() passed scopes = %v, want %v", query.Scopes, tt.expectedScopes)
 					}
-					return []*domain.Document{{ID: "doc-1", Title: "Result 1"}}
+					return []domain.SearchResult{{Document: &domain.Document{ID: "doc-1", Title: "Result 1"}, Score: 1}}
 				},
 			}
 
 			uc := New(mockRepo)
-			result := uc.Execute(tt.keywords, tt.filePath, false)
+			result, err := uc.Execute(context.Background(), Request{Keywords: tt.keywords, FilePath: tt.filePath})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
 
 			if len(result.Documents) != 1 {
 				t.Errorf("Execute() expected 1 document, got %d", len(result.Documents))
@@ -85,3 +89,155 @@ func TestUseCase_Execute(t *testing.T) {
 		})
 	}
 }
+
+func TestUseCase_Execute_Query(t *testing.T) {
+	tests := []struct {
+		name     string
+		req      Request
+		wantExpr domain.QueryNode
+		wantErr  bool
+	}{
+		{
+			name:     "it should keep keyword search when no query is given",
+			req:      Request{Keywords: []string{"testing"}},
+			wantExpr: nil,
+		},
+		{
+			name:     "it should pass the parsed query",
+			req:      Request{Query: "scope:go -legacy"},
+			wantExpr: domain.AndNode{Children: []domain.QueryNode{domain.TermNode{Field: "scope", Value: "go"}, domain.NotNode{Child: domain.TermNode{Value: "legacy"}}}},
+		},
+		{
+			name: "it should require one of the keywords alongside the query",
+			req:  Request{Query: "status:draft", Keywords: []string{"go", "golang"}},
+			wantExpr: domain.AndNode{Children: []domain.QueryNode{
+				domain.TermNode{Field: "status", Value: "draft"},
+				domain.OrNode{Children: []domain.QueryNode{domain.TermNode{Value: "go"}, domain.TermNode{Value: "golang"}}},
+			}},
+		},
+		{
+			name:    "it should reject invalid queries",
+			req:     Request{Query: "unknown:field"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got domain.QueryNode
+			uc := New(&MockRepository{
+				SearchFunc: func(query domain.SearchQuery) []domain.SearchResult {
+					got = query.Expr
+					return nil
+				},
+			})
+
+			_, err := uc.Execute(context.Background(), tt.req)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Execute() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.wantExpr) {
+				t.Errorf("Execute() passed expr = %#v, want %#v", got, tt.wantExpr)
+			}
+		})
+	}
+}
+
+func TestUseCase_Execute_Paging(t *testing.T) {
+	var ranked []domain.SearchResult
+	for n := 0; n < 5; n++ {
+		ranked = append(ranked, domain.SearchResult{
+			Document: &domain.Document{ID: string(rune('a' + n))},
+			Score:    float64(5 - n),
+		})
+	}
+	uc := New(&MockRepository{
+		SearchFunc: func(query domain.SearchQuery) []domain.SearchResult { return ranked },
+	})
+
+	ids := func(r Result) string {
+		var s string
+		for _, d := range r.Documents {
+			s += d.ID
+		}
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		req       Request
+		wantIDs   string
+		wantTotal int
+		wantNext  bool
+	}{
+		{
+			name:      "it should return the first page with a cursor",
+			req:       Request{Keywords: []string{"x"}, Limit: 2},
+			wantIDs:   "ab",
+			wantTotal: 5,
+			wantNext:  true,
+		},
+		{
+			name:      "it should skip documents by offset",
+			req:       Request{Keywords: []string{"x"}, Limit: 2, Offset: 4},
+			wantIDs:   "e",
+			wantTotal: 5,
+		},
+		{
+			name:      "it should continue from a cursor",
+			req:       Request{Keywords: []string{"x"}, Limit: 2, Cursor: encodeCursor(2)},
+			wantIDs:   "cd",
+			wantTotal: 5,
+			wantNext:  true,
+		},
+		{
+			name:      "it should drop documents below the minimum score",
+			req:       Request{Keywords: []string{"x"}, MinScore: 3},
+			wantIDs:   "abc",
+			wantTotal: 3,
+		},
+		{
+			name:      "it should return nothing past the last page",
+			req:       Request{Keywords: []string{"x"}, Offset: 10},
+			wantIDs:   "",
+			wantTotal: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := uc.Execute(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if ids(got) != tt.wantIDs {
+				t.Errorf("Execute() IDs = %q, want %q", ids(got), tt.wantIDs)
+			}
+			if got.Total != tt.wantTotal {
+				t.Errorf("Execute() Total = %d, want %d", got.Total, tt.wantTotal)
+			}
+			if (got.NextCursor != "") != tt.wantNext {
+				t.Errorf("Execute() NextCursor = %q, want next page %v", got.NextCursor, tt.wantNext)
+			}
+		})
+	}
+
+	t.Run("it should reject malformed cursors", func(t *testing.T) {
+		if _, err := uc.Execute(context.Background(), Request{Keywords: []string{"x"}, Cursor: "not-a-cursor"}); err == nil {
+			t.Error("expected an error for a malformed cursor")
+		}
+	})
+}
+
+func TestUseCase_Execute_Cancelled(t *testing.T) {
+	t.Run("it should stop once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		uc := New(&MockRepository{SearchFunc: func(query domain.SearchQuery) []domain.SearchResult {
+			cancel()
+			return []domain.SearchResult{{Document: &domain.Document{ID: "errors"}}}
+		}})
+		if _, err := uc.Execute(ctx, Request{Keywords: []string{"errors"}}); err != context.Canceled {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -2,6 +2,7 @@ package validator
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/mew-ton/kex/internal/domain"
 )
@@ -20,3 +21,188 @@ func (r *TitleRequiredRule) Validate(doc *domain.Document) error {
 	}
 	return nil
 }
+
+// AppliesToPatternRule ensures appliesTo patterns are valid globs
+type AppliesToPatternRule struct{}
+
+func (r *AppliesToPatternRule) Validate(doc *domain.Document) error {
+	for _, pattern := range doc.AppliesTo {
+		if err := domain.ValidatePathPattern(pattern); err != nil {
+			return fmt.Errorf("invalid appliesTo pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// RelationsRule ensures related, supersedes, requires, replacedBy and overrides refer to existing documents
+type RelationsRule struct {
+	Repo domain.DocumentRepository
+
+	ids map[string]struct{}
+}
+
+func (r *RelationsRule) Validate(doc *domain.Document) error {
+	if r.ids == nil {
+		r.ids = make(map[string]struct{})
+		for _, d := range r.Repo.GetAll() {
+			r.ids[d.ID] = struct{}{}
+		}
+	}
+
+	var problems []string
+	for _, link := range doc.Links() {
+		if link.ID == doc.ID {
+			problems = append(problems, fmt.Sprintf("%s refers to the document itself", link.Relation))
+			continue
+		}
+		if _, ok := r.ids[link.ID]; !ok {
+			problems = append(problems, fmt.Sprintf("%s refers to unknown document %q", link.Relation, link.ID))
+		}
+	}
+	if doc.Overrides != "" && doc.Overrides != doc.ID {
+		if _, ok := r.ids[doc.Overrides]; !ok {
+			problems = append(problems, fmt.Sprintf("overrides refers to unknown document %q", doc.Overrides))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// SeverityRule ensures severity is one of must, should or may
+type SeverityRule struct{}
+
+func (r *SeverityRule) Validate(doc *domain.Document) error {
+	if !doc.Severity.IsValid() {
+		return fmt.Errorf("invalid severity %q (expected must, should or may)", doc.Severity)
+	}
+	return nil
+}
+
+// StatusRule ensures status is known and replacedBy is only set on retired documents
+type StatusRule struct{}
+
+func (r *StatusRule) Validate(doc *domain.Document) error {
+	if !doc.Status.IsValid() {
+		return fmt.Errorf("invalid status %q (expected draft, adopted, deprecated or archived)", doc.Status)
+	}
+	if doc.ReplacedBy != "" && !doc.Status.IsRetired() {
+		return fmt.Errorf("replacedBy requires status deprecated or archived")
+	}
+	return nil
+}
+
+// DeprecatedLinksRule ensures adopted documents do not link to deprecated or archived ones.
+// Supersedes is exempt, since replacing a retired document is its purpose.
+type DeprecatedLinksRule struct {
+	Repo domain.DocumentRepository
+
+	docs map[string]*domain.Document
+}
+
+func (r *DeprecatedLinksRule) Validate(doc *domain.Document) error {
+	if doc.Status != domain.StatusAdopted {
+		return nil
+	}
+	if r.docs == nil {
+		r.docs = make(map[string]*domain.Document)
+		for _, d := range r.Repo.GetAll() {
+			r.docs[d.ID] = d
+		}
+	}
+
+	var problems []string
+	for _, link := range doc.Links() {
+		if link.Relation == domain.RelationSupersedes {
+			continue
+		}
+		target, ok := r.docs[link.ID]
+		if !ok || !target.Status.IsRetired() {
+			continue
+		}
+		problem := fmt.Sprintf("%s refers to %s document %q", link.Relation, target.Status, link.ID)
+		if target.ReplacedBy != "" {
+			problem += fmt.Sprintf(" (replaced by %q)", target.ReplacedBy)
+		}
+		problems = append(problems, problem)
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// OverrideRule ensures overrides refers to another document with a known mode
+type OverrideRule struct{}
+
+func (r *OverrideRule) Validate(doc *domain.Document) error {
+	if doc.Overrides == "" {
+		if doc.OverrideMode != "" {
+			return fmt.Errorf("overrideMode requires overrides")
+		}
+		return nil
+	}
+	if doc.Overrides == doc.ID {
+		return fmt.Errorf("overrides refers to the document itself")
+	}
+	if !doc.OverrideMode.IsValid() {
+		return fmt.Errorf("invalid overrideMode %q (expected replace or extend)", doc.OverrideMode)
+	}
+	return nil
+}
+
+// PromptRule ensures kind is known and prompts declare usable arguments and a valid template.
+// Templates are parsed when Repo is set (bodies are loaded on demand).
+type PromptRule struct {
+	Repo domain.DocumentRepository
+}
+
+func (r *PromptRule) Validate(doc *domain.Document) error {
+	if !doc.Kind.IsValid() {
+		return fmt.Errorf("invalid kind %q (expected guideline or prompt)", doc.Kind)
+	}
+	if !doc.IsPrompt() {
+		if len(doc.Arguments) > 0 {
+			return fmt.Errorf("arguments requires kind prompt")
+		}
+		return nil
+	}
+
+	seen := make(map[string]struct{})
+	for _, arg := range doc.Arguments {
+		if !isIdentifier(arg.Name) {
+			return fmt.Errorf("invalid argument name %q (use letters, digits and underscores)", arg.Name)
+		}
+		if _, dup := seen[arg.Name]; dup {
+			return fmt.Errorf("duplicate argument %q", arg.Name)
+		}
+		seen[arg.Name] = struct{}{}
+	}
+
+	if r.Repo == nil {
+		return nil
+	}
+	loaded, ok := r.Repo.GetByID(doc.ID)
+	if !ok {
+		return nil
+	}
+	if _, err := loaded.PromptTemplate(nil); err != nil {
+		return fmt.Errorf("invalid prompt template: %w", err)
+	}
+	return nil
+}
+
+// isIdentifier reports whether the name can be used as {{.name}} in a template
+func isIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+	for n, c := range name {
+		letter := c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+		if !letter && (n == 0 || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
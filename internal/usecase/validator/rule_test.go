@@ -34,3 +34,297 @@ func TestTitleRequiredRule_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestAppliesToPatternRule_Validate(t *testing.T) {
+	rule := &AppliesToPatternRule{}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid without appliesTo",
+			doc:     &domain.Document{Title: "Some Title"},
+			wantErr: false,
+		},
+		{
+			name:    "valid with glob patterns",
+			doc:     &domain.Document{AppliesTo: []string{"**/*_test.go", "migrations/**"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid with a malformed pattern",
+			doc:     &domain.Document{AppliesTo: []string{"src/[a-"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRelationsRule_Validate(t *testing.T) {
+	repo := &MockRepository{
+		GetAllFunc: func() []*domain.Document {
+			return []*domain.Document{
+				{ID: "errors", Title: "Error Handling"},
+				{ID: "logging", Title: "Logging"},
+			}
+		},
+	}
+	rule := &RelationsRule{Repo: repo}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid when relations refer to existing documents",
+			doc:     &domain.Document{ID: "errors", Requires: []string{"logging"}, Related: []string{"logging"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid when a relation refers to an unknown document",
+			doc:     &domain.Document{ID: "errors", Supersedes: []string{"old-errors"}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid when a document refers to itself",
+			doc:     &domain.Document{ID: "errors", Related: []string{"errors"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSeverityRule_Validate(t *testing.T) {
+	rule := &SeverityRule{}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid without severity",
+			doc:     &domain.Document{},
+			wantErr: false,
+		},
+		{
+			name:    "valid with a known severity",
+			doc:     &domain.Document{Severity: domain.SeverityMust},
+			wantErr: false,
+		},
+		{
+			name:    "invalid with an unknown severity",
+			doc:     &domain.Document{Severity: "critical"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestStatusRule_Validate(t *testing.T) {
+	rule := &StatusRule{}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid with a deprecated status and a replacement",
+			doc:     &domain.Document{Status: domain.StatusDeprecated, ReplacedBy: "logging"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid with an unknown status",
+			doc:     &domain.Document{Status: "retired"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid when an adopted document has replacedBy",
+			doc:     &domain.Document{Status: domain.StatusAdopted, ReplacedBy: "logging"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestDeprecatedLinksRule_Validate(t *testing.T) {
+	repo := &MockRepository{
+		GetAllFunc: func() []*domain.Document {
+			return []*domain.Document{
+				{ID: "logging", Status: domain.StatusAdopted},
+				{ID: "old-logging", Status: domain.StatusDeprecated, ReplacedBy: "logging"},
+			}
+		},
+	}
+	rule := &DeprecatedLinksRule{Repo: repo}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid when links refer to adopted documents",
+			doc:     &domain.Document{ID: "errors", Status: domain.StatusAdopted, Requires: []string{"logging"}},
+			wantErr: false,
+		},
+		{
+			name:    "valid when superseding a deprecated document",
+			doc:     &domain.Document{ID: "logging", Status: domain.StatusAdopted, Supersedes: []string{"old-logging"}},
+			wantErr: false,
+		},
+		{
+			name:    "valid when a draft links to a deprecated document",
+			doc:     &domain.Document{ID: "errors", Status: domain.StatusDraft, Related: []string{"old-logging"}},
+			wantErr: false,
+		},
+		{
+			name:    "invalid when an adopted document links to a deprecated document",
+			doc:     &domain.Document{ID: "errors", Status: domain.StatusAdopted, Requires: []string{"old-logging"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOverrideRule_Validate(t *testing.T) {
+	rule := &OverrideRule{}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid when extending another document",
+			doc:     &domain.Document{ID: "errors", Overrides: "org:errors", OverrideMode: domain.OverrideExtend},
+			wantErr: false,
+		},
+		{
+			name:    "invalid when overriding itself",
+			doc:     &domain.Document{ID: "errors", Overrides: "errors"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid with an unknown mode",
+			doc:     &domain.Document{ID: "errors", Overrides: "org:errors", OverrideMode: "merge"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid with a mode but no overrides",
+			doc:     &domain.Document{ID: "errors", OverrideMode: domain.OverrideReplace},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPromptRule_Validate(t *testing.T) {
+	bodies := map[string]string{
+		"review": "Review {{.file}} against our guidelines.",
+		"broken": "Review {{.file",
+	}
+	repo := &MockRepository{
+		GetByIDFunc: func(id string) (*domain.Document, bool) {
+			return &domain.Document{ID: id, Kind: domain.KindPrompt, Body: bodies[id]}, true
+		},
+	}
+	rule := &PromptRule{Repo: repo}
+	file := domain.PromptArgument{Name: "file", Required: true}
+
+	tests := []struct {
+		name    string
+		doc     *domain.Document
+		wantErr bool
+	}{
+		{
+			name:    "valid for a prompt with arguments and a template",
+			doc:     &domain.Document{ID: "review", Kind: domain.KindPrompt, Arguments: []domain.PromptArgument{file}},
+			wantErr: false,
+		},
+		{
+			name:    "valid for a guideline without arguments",
+			doc:     &domain.Document{ID: "errors", Kind: domain.KindGuideline},
+			wantErr: false,
+		},
+		{
+			name:    "invalid with an unknown kind",
+			doc:     &domain.Document{ID: "errors", Kind: "snippet"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid when a guideline declares arguments",
+			doc:     &domain.Document{ID: "errors", Arguments: []domain.PromptArgument{file}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid with an argument name templates cannot use",
+			doc:     &domain.Document{ID: "review", Kind: domain.KindPrompt, Arguments: []domain.PromptArgument{{Name: "file-path"}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid with duplicate arguments",
+			doc:     &domain.Document{ID: "review", Kind: domain.KindPrompt, Arguments: []domain.PromptArgument{file, file}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid when the template does not parse",
+			doc:     &domain.Document{ID: "broken", Kind: domain.KindPrompt},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := rule.Validate(tt.doc); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
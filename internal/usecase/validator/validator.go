@@ -17,6 +17,7 @@ type CheckStats struct {
 	Total         int `json:"total"`
 	Adopted       int `json:"adopted"`
 	Draft         int `json:"draft"`
+	Deprecated    int `json:"deprecated"` // Deprecated and archived
 	AdoptedErrors int `json:"adopted_errors"`
 	DraftWarnings int `json:"draft_warnings"`
 	ParseErrors   int `json:"parse_errors"`
@@ -65,6 +66,8 @@ func (v *Validator) Validate(repo domain.DocumentRepository) ValidationReport {
 
 		if doc.Status == domain.StatusDraft {
 			report.Stats.Draft++
+		} else if doc.Status.IsRetired() {
+			report.Stats.Deprecated++
 		} else {
 			report.Stats.Adopted++
 		}
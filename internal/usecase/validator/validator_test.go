@@ -11,6 +11,7 @@ import (
 type MockRepository struct {
 	GetAllFunc    func() []*domain.Document
 	GetErrorsFunc func() []error
+	GetByIDFunc   func(id string) (*domain.Document, bool)
 }
 
 // Implement only necessary methods for Validator
@@ -28,10 +29,16 @@ func (m *MockRepository) GetErrors() []error {
 	return nil
 }
 
+func (m *MockRepository) GetByID(id string) (*domain.Document, bool) {
+	if m.GetByIDFunc != nil {
+		return m.GetByIDFunc(id)
+	}
+	return nil, false
+}
+
 // Unused methods
-func (m *MockRepository) GetByID(id string) (*domain.Document, bool)      { return nil, false }
-func (m *MockRepository) Search(k, s []string, e bool) []*domain.Document { return nil }
-func (m *MockRepository) Load() error                                     { return nil }
+func (m *MockRepository) Search(q domain.SearchQuery) []domain.SearchResult { return nil }
+func (m *MockRepository) Load() error                                       { return nil }
 
 func TestValidate(t *testing.T) {
 	tests := []struct {